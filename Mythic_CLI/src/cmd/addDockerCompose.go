@@ -19,14 +19,20 @@ var addDockerComposeCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(addDockerComposeCmd)
+	addDockerComposeCmd.Flags().BoolVar(
+		&internal.ResetServiceConfiguration,
+		"reset",
+		false,
+		`Overwrite the entire docker-compose entry for the service instead of preserving manual changes`,
+	)
 }
 
 func addDockerCompose(cmd *cobra.Command, args []string) {
 	if utils.StringInSlice(args[0], config.MythicPossibleServices) {
-		internal.AddMythicService(args[0], true)
+		internal.InstallMythicService(args[0], true)
 		return
 	}
-	err := internal.Add3rdPartyService(args[0], make(map[string]interface{}), true)
+	err := internal.Install3rdPartyService(args[0], make(map[string]interface{}), true)
 	if err != nil {
 		log.Printf("[-] Failed to add service")
 	}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
 	"github.com/spf13/cobra"
+	"os"
 )
 
 // configCmd represents the config command
@@ -12,13 +15,63 @@ var buildCmd = &cobra.Command{
 	Long:  `Run this command to build or rebuild a specific container by specifying container names.`,
 	Run:   buildContainer,
 }
+var buildParallel int
+var buildNoCache bool
+var buildOnly bool
 
 func init() {
 	rootCmd.AddCommand(buildCmd)
+	buildCmd.Flags().IntVarP(
+		&buildParallel,
+		"parallel",
+		"p",
+		0,
+		`Maximum number of containers to build at the same time, defaults to the number of CPUs`,
+	)
+	buildCmd.Flags().BoolVar(
+		&buildNoCache,
+		"no-cache",
+		false,
+		`Build the images from scratch without using any cached layers`,
+	)
+	buildCmd.Flags().BoolVar(
+		&buildOnly,
+		"build-only",
+		false,
+		`Only build the new images and leave the running containers alone, start the services later to switch to the new images`,
+	)
+	buildCmd.Flags().StringSliceVar(
+		&manager.BuildPlatforms,
+		"platform",
+		manager.BuildPlatforms,
+		`Target platforms to build the images for with docker buildx, ex: linux/amd64,linux/arm64. More than one platform requires --push`,
+	)
+	buildCmd.Flags().StringVar(
+		&manager.BuildPushRegistry,
+		"push",
+		manager.BuildPushRegistry,
+		`Registry prefix (ex: registry.local:5000/mythic) to push images built for more than one --platform to.
+The image for this host's platform is then pulled back so it can be used locally.
+Log in with 'docker login' first if needed, both the push and the pull use those credentials unless docker_registry_username is set`,
+	)
+	buildCmd.Flags().StringArrayVar(
+		&manager.BuildArgs,
+		"build-arg",
+		manager.BuildArgs,
+		`Set a KEY=VALUE build argument for just this build, can be used multiple times and takes precedence over build.env`,
+	)
+	buildCmd.Flags().StringArrayVar(
+		&manager.BuildSecrets,
+		"secret",
+		manager.BuildSecrets,
+		`Pass a BuildKit secret to the build (ex: id=GITHUB_TOKEN,env=GITHUB_TOKEN or id=GITHUB_TOKEN,src=/path/to/token), requires docker buildx.
+Secrets can also be listed as ID=/path/to/file in build_secrets.env`,
+	)
 }
 
 func buildContainer(cmd *cobra.Command, args []string) {
-	if err := internal.ServiceBuild(args); err != nil {
-
+	if err := internal.ServiceBuild(args, buildParallel, buildNoCache, buildOnly); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// cleanupCmd represents the cleanup command
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Remove containers left behind by services that no longer exist",
+	Long: `Run this command to find containers from renamed or uninstalled services that aren't Mythic services, in docker-compose, or on disk.
+They're listed and then removed after you confirm. Only containers docker compose created for this Mythic install are checked.`,
+	Run:  cleanup,
+	Args: cobra.NoArgs,
+}
+
+var cleanupForce bool
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd)
+	cleanupCmd.Flags().BoolVarP(
+		&cleanupForce,
+		"force",
+		"f",
+		false,
+		`Don't prompt for confirmation before removing the containers`,
+	)
+}
+
+func cleanup(cmd *cobra.Command, args []string) {
+	if err := internal.CleanupOrphanedContainers(cleanupForce); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
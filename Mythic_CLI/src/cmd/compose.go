@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// composeCmd represents the compose command
+var composeCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "Work with docker-compose files",
+	Long:  `Run this command's subcommands to inspect docker-compose files.`,
+	Run:   compose,
+}
+
+func init() {
+	rootCmd.AddCommand(composeCmd)
+}
+
+func compose(cmd *cobra.Command, args []string) {
+	cmd.Help()
+}
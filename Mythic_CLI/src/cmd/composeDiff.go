@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// composeDiffCmd represents the compose diff command
+var composeDiffCmd = &cobra.Command{
+	Use:   "diff [first file] [second file]",
+	Short: "Display the differences between two docker-compose files",
+	Long: `Run this command to compare two docker-compose files, like a user's against a known good one.
+Services that are only in one file are listed, and for services in both, the keys that were removed (-), added (+), or changed (~).
+The order of keys doesn't matter, and environment lists are compared the same as environment maps.`,
+	Run:  composeDiff,
+	Args: cobra.ExactArgs(2),
+}
+
+func init() {
+	composeCmd.AddCommand(composeDiffCmd)
+}
+
+func composeDiff(cmd *cobra.Command, args []string) {
+	if err := internal.DiffComposeFiles(args[0], args[1]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScalarSettingString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		value  interface{}
+		want   string
+		wantOk bool
+	}{
+		{name: "string", value: "mythic_server", want: "mythic_server", wantOk: true},
+		{name: "empty string", value: "", want: "", wantOk: true},
+		{name: "bool", value: true, want: "true", wantOk: true},
+		{name: "int", value: 7443, want: "7443", wantOk: true},
+		{name: "uint", value: uint(17443), want: "17443", wantOk: true},
+		{name: "float", value: 1.5, want: "1.5", wantOk: true},
+		{name: "slice", value: []string{"a", "b"}, wantOk: false},
+		{name: "interface slice", value: []interface{}{"a"}, wantOk: false},
+		{name: "map", value: map[string]interface{}{"a": "b"}, wantOk: false},
+		{name: "nil", value: nil, wantOk: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := scalarSettingString(tt.value)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("scalarSettingString() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestValidateEnv(t *testing.T) {
+	t.Parallel()
+	defaults := map[string]string{
+		"nginx_host":    "mythic_nginx",
+		"nginx_port":    "7443",
+		"nginx_use_ssl": "true",
+	}
+	env := map[string]EffectiveEnvValue{}
+	for _, key := range requiredSettings {
+		env[strings.ToUpper(key)] = EffectiveEnvValue{Value: "value"}
+	}
+	env["NGINX_HOST"] = EffectiveEnvValue{Value: "mythic_nginx"}
+	env["NGINX_PORT"] = EffectiveEnvValue{Value: "7443"}
+	env["NGINX_USE_SSL"] = EffectiveEnvValue{Value: "true"}
+	if issues := validateEnv(env, defaults); len(issues) != 0 {
+		t.Errorf("validateEnv() with valid settings = %v, want no issues", issues)
+	}
+	delete(env, "JWT_SECRET")
+	delete(env, "NGINX_HOST")
+	env["NGINX_PORT"] = EffectiveEnvValue{Value: "74430"}
+	env["NGINX_USE_SSL"] = EffectiveEnvValue{Value: "yes"}
+	env["HTTP_PORT"] = EffectiveEnvValue{Value: "http"}
+	env["REMOTE_HOST"] = EffectiveEnvValue{Value: "my host"}
+	var got []string
+	for _, issue := range validateEnv(env, defaults) {
+		got = append(got, fmt.Sprintf("%s %v", issue.Key, issue.Fatal))
+	}
+	want := []string{
+		"JWT_SECRET true",
+		"NGINX_HOST true",
+		"HTTP_PORT true",
+		"NGINX_PORT true",
+		"NGINX_USE_SSL false",
+		"REMOTE_HOST true",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("validateEnv() = %v, want %v", got, want)
+	}
+}
+
+func TestGetSecretValues(t *testing.T) {
+	t.Parallel()
+	settings := map[string]interface{}{
+		"postgres_password":                    "pgPassw0rdValue",
+		"rabbitmq_password":                    "false",
+		"jwt_secret":                           "12345678901",
+		"hasura_secret":                        "short",
+		"mythic_admin_password":                nil,
+		"mythic_server_host":                   "mythic_server",
+		"jupyter_token":                        "mythic",
+		"use_docker_secrets":                   false,
+		"installed_service_use_docker_secrets": "false",
+	}
+	secretValues := getSecretValues(func(key string) interface{} {
+		return settings[key]
+	})
+	if want := []string{"pgPassw0rdValue"}; !reflect.DeepEqual(secretValues, want) {
+		t.Errorf("getSecretValues() = %v, want %v", secretValues, want)
+	}
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "host", value: "MYTHIC_SERVER_HOST=mythic_server", want: "MYTHIC_SERVER_HOST=mythic_server"},
+		{name: "bool", value: "USE_DOCKER_SECRETS=false", want: "USE_DOCKER_SECRETS=false"},
+		{name: "embedded password", value: "host=mythic_postgres password=pgPassw0rdValue", want: "host=mythic_postgres password=" + RedactedValue},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := RedactSecretValues(tt.value, secretValues); got != tt.want {
+				t.Errorf("RedactSecretValues(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSecretSetting(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "postgres_password", want: true},
+		{key: "JWT_SECRET", want: true},
+		{key: "jupyter_token", want: true},
+		{key: "use_docker_secrets", want: false},
+		{key: "INSTALLED_SERVICE_USE_DOCKER_SECRETS", want: false},
+		{key: "mythic_server_host", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+			if got := IsSecretSetting(tt.key); got != tt.want {
+				t.Errorf("IsSecretSetting(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,4 +5,10 @@ package config
 var (
 	// Version Mythic CLI version
 	Version = "v0.2.22"
+	// DockerSecrets maps the names of the file based secrets used with use_docker_secrets to the .env values they hold
+	DockerSecrets = map[string]string{
+		"postgres_password": "POSTGRES_PASSWORD",
+		"rabbitmq_password": "RABBITMQ_PASSWORD",
+		"jwt_secret":        "JWT_SECRET",
+	}
 )
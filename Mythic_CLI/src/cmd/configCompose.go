@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// configComposeCmd represents the config compose command
+var configComposeCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "Display the fully resolved docker-compose configuration",
+	Long: `Run this command to print the docker-compose configuration that Mythic uses with all environment variables substituted.
+This is useful to see exactly what ports, environment variables, and volumes each service gets.`,
+	Run:  configCompose,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	configCmd.AddCommand(configComposeCmd)
+}
+
+func configCompose(cmd *cobra.Command, args []string) {
+	if err := internal.DockerComposeConfig(); err != nil {
+		fmt.Printf("%v\n", err)
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// configDiffCmd represents the config diff command
+var configDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Display only the settings that differ from Mythic's defaults",
+	Long: `Display the settings that have been changed from the shipped defaults along with the default value and where the change came from.
+Settings that aren't part of Mythic's defaults, like ones for installed services, are listed too.
+Passwords and secrets that are randomly generated on install aren't compared. Other secrets are redacted unless --show-secrets is used.`,
+	Run:  configDiff,
+	Args: cobra.NoArgs,
+}
+
+var configDiffJSON bool
+var configDiffShowSecrets bool
+
+func init() {
+	configCmd.AddCommand(configDiffCmd)
+	configDiffCmd.Flags().BoolVar(
+		&configDiffJSON,
+		"json",
+		false,
+		`Output the differences as JSON`,
+	)
+	configDiffCmd.Flags().BoolVar(
+		&configDiffShowSecrets,
+		"show-secrets",
+		false,
+		`Show the actual values of passwords, secrets, and tokens`,
+	)
+}
+
+func configDiff(cmd *cobra.Command, args []string) {
+	if err := internal.PrintEnvDiff(configDiffJSON, configDiffShowSecrets); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/config"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// configDumpCmd represents the config dump command
+var configDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Display the effective configuration and where each value came from",
+	Long: `Display the effective configuration values given to services along with the file that provided each one.
+Values in the file pointed to by the MYTHIC_ENV_OVERRIDE environment variable take precedence over .env.
+Use --format yaml or --format json to get the full environment docker compose runs with plus the parsed services,
+which is what to attach when reporting an issue. Passwords, secrets, and tokens are redacted unless --show-secrets is used.`,
+	Run:  configDump,
+	Args: cobra.NoArgs,
+}
+
+var configDumpFormat string
+var configDumpShowSecrets bool
+
+func init() {
+	configCmd.AddCommand(configDumpCmd)
+	configDumpCmd.Flags().StringVar(
+		&configDumpFormat,
+		"format",
+		"",
+		`Output the effective configuration and services as yaml or json instead of a table`,
+	)
+	configDumpCmd.Flags().BoolVar(
+		&configDumpShowSecrets,
+		"show-secrets",
+		false,
+		`Show the actual values of passwords, secrets, and tokens`,
+	)
+}
+
+func configDump(cmd *cobra.Command, args []string) {
+	if configDumpFormat != "" {
+		if err := internal.DumpEffectiveConfig(configDumpFormat, configDumpShowSecrets); err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	// initialize tabwriter
+	writer := new(tabwriter.Writer)
+	// Set minwidth, tabwidth, padding, padchar, and flags
+	writer.Init(os.Stdout, 8, 8, 1, '\t', 0)
+
+	defer writer.Flush()
+
+	fmt.Println("[+] Effective configuration:")
+	fmt.Fprintf(writer, "\n %s\t%s\t%s", "Setting", "Value", "Source")
+	fmt.Fprintf(writer, "\n %s\t%s\t%s", "–––––––", "–––––––", "–––––––")
+
+	configuration := config.GetEffectiveEnv(false)
+	keys := make([]string, 0, len(configuration))
+	for k := range configuration {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := configuration[key].Value
+		if !configDumpShowSecrets && value != "" && config.IsSecretSetting(key) {
+			value = config.RedactedValue
+		}
+		fmt.Fprintf(writer, "\n %s\t%s\t%s", key, value, configuration[key].Source)
+	}
+	fmt.Fprintln(writer, "")
+}
@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
+	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
 	"github.com/spf13/cobra"
+	"log"
 )
 
 // configSetCmd represents the configSet command
@@ -21,5 +23,14 @@ func init() {
 }
 
 func configSet(cmd *cobra.Command, args []string) {
-	config.SetConfigStrings(args[0], args[1])
+	if _, err := config.GetConfigValue(args[0]); err != nil {
+		// not an exact key, so treat it as a regex for matching keys
+		config.SetConfigStrings(args[0], args[1])
+		return
+	}
+	if err := config.SetConfigValue(args[0], args[1]); err != nil {
+		log.Printf("[-] Failed to set %s: %v\n", args[0], err)
+		return
+	}
+	utils.LogInfo("[+] Configuration successfully updated.\n")
 }
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// connectCmd represents the connect command
+var connectCmd = &cobra.Command{
+	Use:   "connect",
+	Short: "Display the addresses of Mythic's services",
+	Long: `Run this command to display the web addresses, bind settings, and logins of Mythic's services based on the current .env configuration.
+Passwords are masked unless --show-secrets is used.`,
+	Run: connect,
+}
+
+var connectJSON bool
+var connectShowSecrets bool
+
+func init() {
+	rootCmd.AddCommand(connectCmd)
+	connectCmd.Flags().BoolVar(
+		&connectJSON,
+		"json",
+		false,
+		`Output the connection information as a JSON array`,
+	)
+	connectCmd.Flags().BoolVar(
+		&connectShowSecrets,
+		"show-secrets",
+		false,
+		`Display the real passwords instead of masking them`,
+	)
+}
+
+func connect(cmd *cobra.Command, args []string) {
+	internal.ConnectionInfo(connectJSON, connectShowSecrets)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// copyCmd represents the cp command
+var copyCmd = &cobra.Command{
+	Use:   "cp [source] [destination]",
+	Short: "Copy files or folders between local disk and a running service",
+	Long: `Run this command to copy a file or folder into or out of a service's container, like docker cp.
+The container side is written as service:/path, for example:
+	./mythic-cli cp ./default.conf mythic_nginx:/etc/nginx/conf.d/default.conf
+	./mythic-cli cp mythic_server:/Mythic/mythic/logs ./server_logs
+The service needs to be running. Use the volume commands to copy into volumes instead.`,
+	Run:  copyFiles,
+	Args: cobra.ExactArgs(2),
+}
+
+func init() {
+	rootCmd.AddCommand(copyCmd)
+}
+
+func copyFiles(cmd *cobra.Command, args []string) {
+	if err := internal.ServiceCopy(args[0], args[1]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
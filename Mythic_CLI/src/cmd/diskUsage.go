@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// diskUsageCmd represents the du command
+var diskUsageCmd = &cobra.Command{
+	Use:     "du",
+	Aliases: []string{"disk-usage"},
+	Short:   "Show how much disk each service uses",
+	Long: `Run this command to see the image size, container writable layer size, and volume sizes for each service.
+Services are sorted by their total footprint so the ones using the most disk are listed first.`,
+	Run:  diskUsage,
+	Args: cobra.NoArgs,
+}
+
+var diskUsageJSON bool
+
+func init() {
+	rootCmd.AddCommand(diskUsageCmd)
+	diskUsageCmd.Flags().BoolVar(
+		&diskUsageJSON,
+		"json",
+		false,
+		`Output the disk usage as JSON`,
+	)
+}
+
+func diskUsage(cmd *cobra.Command, args []string) {
+	if err := internal.ServiceDiskUsage(diskUsageJSON); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
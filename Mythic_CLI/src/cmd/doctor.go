@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the environment for common problems",
+	Long: `Run this command to check the Docker version and daemon, the docker-compose file, disk usage, and port availability.
+Each check reports pass, warn, or fail along with a hint on how to fix it. Exits non-zero if any check fails.`,
+	Run:         doctor,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{skipInitializeAnnotation: "true"},
+}
+
+var doctorJSON bool
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(
+		&doctorJSON,
+		"json",
+		false,
+		`Output the results as a JSON array`,
+	)
+}
+
+func doctor(cmd *cobra.Command, args []string) {
+	internal.Doctor(doctorJSON)
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// envCmd represents the env command
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Inspect and override the environment of a service",
+	Long: `Run this command's subcommands to see the environment variables a service's container actually received,
+or to override one for a single service without editing .env.`,
+	Run: env,
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+}
+
+func env(cmd *cobra.Command, args []string) {
+	cmd.Help()
+}
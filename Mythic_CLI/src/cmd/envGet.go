@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// envGetCmd represents the env get command
+var envGetCmd = &cobra.Command{
+	Use:   "get [service]",
+	Short: "Display the environment variables a service's container is running with",
+	Long: `Run this command to see exactly which environment variables a service's container received, which helps when a change
+to .env or an override doesn't seem to take effect. Secrets are redacted.`,
+	Run:  envGet,
+	Args: cobra.ExactArgs(1),
+}
+
+var envGetJSON bool
+
+func init() {
+	envCmd.AddCommand(envGetCmd)
+	envGetCmd.Flags().BoolVar(
+		&envGetJSON,
+		"json",
+		false,
+		`Output the environment as a JSON object`,
+	)
+}
+
+func envGet(cmd *cobra.Command, args []string) {
+	if err := internal.PrintContainerEnv(args[0], envGetJSON); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
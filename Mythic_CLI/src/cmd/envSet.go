@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// envSetCmd represents the env set command
+var envSetCmd = &cobra.Command{
+	Use:   "set [service] [KEY=VALUE]",
+	Short: "Override an environment variable for one service",
+	Long: `Run this command to set an environment variable in a service's docker-compose entry, like 'env set mythic_server DEBUG_LEVEL=debug'.
+The override takes precedence over .env and is kept when the service is regenerated on start. Restart the service to apply it.`,
+	Run:  envSet,
+	Args: cobra.ExactArgs(2),
+}
+
+func init() {
+	envCmd.AddCommand(envSetCmd)
+}
+
+func envSet(cmd *cobra.Command, args []string) {
+	if err := internal.ServiceEnvSet(args[0], args[1]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
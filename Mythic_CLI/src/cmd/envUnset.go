@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// envUnsetCmd represents the env unset command
+var envUnsetCmd = &cobra.Command{
+	Use:   "unset [service] [KEY]",
+	Short: "Remove an environment variable override from a service",
+	Long: `Run this command to remove an override added with 'env set'. Mythic's own services get their regular value from .env back
+the next time they're started. Restart the service to apply it.`,
+	Run:  envUnset,
+	Args: cobra.ExactArgs(2),
+}
+
+func init() {
+	envCmd.AddCommand(envUnsetCmd)
+}
+
+func envUnset(cmd *cobra.Command, args []string) {
+	if err := internal.ServiceEnvUnset(args[0], args[1]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -9,15 +9,34 @@ import (
 var healthCmd = &cobra.Command{
 	Use:   "health [container names]",
 	Short: "Check health status of containers",
-	Long:  `Run this command to get the health_check status from a container`,
-	Run:   health,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Run this command to get the health_check status from a container.
+Use --all to check every Mythic and installed service at once and exit non-zero if any of them aren't healthy.`,
+	Run: health,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if healthAll {
+			return nil
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 }
 
+var healthAll bool
+
 func init() {
 	rootCmd.AddCommand(healthCmd)
+	healthCmd.Flags().BoolVarP(
+		&healthAll,
+		"all",
+		"a",
+		false,
+		`Check the overall health of all Mythic and installed services`,
+	)
 }
 
 func health(cmd *cobra.Command, args []string) {
+	if healthAll {
+		internal.DockerOverallHealth()
+		return
+	}
 	internal.DockerHealth(args)
 }
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// healthcheckCmd represents the healthcheck command
+var healthcheckCmd = &cobra.Command{
+	Use:   "healthcheck [service name] [test command]",
+	Short: "Set the healthcheck for a service",
+	Long: `Run this command to give a service a healthcheck or replace the one from its image, like 'healthcheck apollo "pgrep -f main"'.
+The test command is run inside the container with its shell and a non-zero exit code means the service is unhealthy.
+This lets status, health, and waiting for services to be healthy work for services that don't ship with a healthcheck.`,
+	Run:  healthcheck,
+	Args: cobra.ExactArgs(2),
+}
+
+var healthcheckOptions manager.Healthcheck
+
+func init() {
+	rootCmd.AddCommand(healthcheckCmd)
+	healthcheckCmd.Flags().DurationVar(
+		&healthcheckOptions.Interval,
+		"interval",
+		0,
+		`How often to run the test command (ex: 30s), Docker's default is 30s`,
+	)
+	healthcheckCmd.Flags().DurationVar(
+		&healthcheckOptions.Timeout,
+		"timeout",
+		0,
+		`How long the test command can run before it counts as a failure, Docker's default is 30s`,
+	)
+	healthcheckCmd.Flags().IntVar(
+		&healthcheckOptions.Retries,
+		"retries",
+		0,
+		`How many failures in a row before the service is unhealthy, Docker's default is 3`,
+	)
+	healthcheckCmd.Flags().DurationVar(
+		&healthcheckOptions.StartPeriod,
+		"start-period",
+		0,
+		`How long the service has to start up before failures count`,
+	)
+}
+
+func healthcheck(cmd *cobra.Command, args []string) {
+	healthcheckOptions.Test = args[1]
+	if err := internal.ServiceHealthcheck(args[0], healthcheckOptions); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// inspectCmd represents the inspect command
+var inspectCmd = &cobra.Command{
+	Use:   "inspect [service]",
+	Short: "Show the container details for a service",
+	Long: `Run this command to see a service's image, state, health, mounts, published ports, environment, restart policy, and resource limits.
+Secrets in the environment are redacted. Use --json to get the full docker inspect output instead.`,
+	Run:  inspectService,
+	Args: cobra.ExactArgs(1),
+}
+
+var inspectJSON bool
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+	inspectCmd.Flags().BoolVar(
+		&inspectJSON,
+		"json",
+		false,
+		`Output the raw inspect information as JSON`,
+	)
+}
+
+func inspectService(cmd *cobra.Command, args []string) {
+	if err := internal.InspectService(args[0], inspectJSON); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
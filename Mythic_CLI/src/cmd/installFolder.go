@@ -25,6 +25,12 @@ func init() {
 		false,
 		`Force installing from local folder and don't prompt to overwrite files if an older version is already installed'`,
 	)
+	installFolderCmd.Flags().BoolVar(
+		&internal.ResetServiceConfiguration,
+		"reset",
+		false,
+		`Overwrite the entire docker-compose entry for the service instead of preserving manual changes`,
+	)
 }
 
 func installFolder(cmd *cobra.Command, args []string) {
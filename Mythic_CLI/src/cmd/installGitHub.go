@@ -17,6 +17,8 @@ var installGitHubCmd = &cobra.Command{
 	Args:    cobra.RangeArgs(1, 2),
 }
 
+var installGitHubSubPath string
+
 func init() {
 	installCmd.AddCommand(installGitHubCmd)
 	installGitHubCmd.Flags().BoolVarP(
@@ -31,7 +33,20 @@ func init() {
 		"branch",
 		"b",
 		"",
-		`Install a specific branch from GitHub instead of the main/master branch`,
+		`Install a specific branch or tag from GitHub instead of the main/master branch`,
+	)
+	installGitHubCmd.Flags().StringVarP(
+		&installGitHubSubPath,
+		"path",
+		"p",
+		"",
+		`Only install this folder of the repository, for repositories with more than one service folder`,
+	)
+	installGitHubCmd.Flags().BoolVar(
+		&internal.ResetServiceConfiguration,
+		"reset",
+		false,
+		`Overwrite the entire docker-compose entry for the service instead of preserving manual changes`,
 	)
 }
 
@@ -39,7 +54,7 @@ func installGitHub(cmd *cobra.Command, args []string) {
 	if len(args) == 2 {
 		branch = args[1]
 	}
-	if err := internal.InstallService(args[0], branch, force); err != nil {
+	if err := internal.InstallService(args[0], branch, installGitHubSubPath, force); err != nil {
 		fmt.Printf("[-] Failed to install service: %v\n", err)
 		os.Exit(1)
 	} else {
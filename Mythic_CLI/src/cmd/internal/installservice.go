@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
 	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
@@ -22,7 +23,7 @@ func InstallFolder(installPath string, overWrite bool) error {
 	var installConfig = viper.New()
 	installConfig.SetConfigName("config")
 	installConfig.SetConfigType("json")
-	log.Printf("[*] Parsing config.json\n")
+	utils.LogVerbose("[*] Parsing config.json\n")
 	installConfig.AddConfigPath(installPath)
 	if err := installConfig.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -56,51 +57,51 @@ func InstallFolder(installPath string, overWrite bool) error {
 
 				if utils.DirExists(filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), f.Name())) {
 					if overWrite || config.AskConfirm("[*] "+f.Name()+" already exists. Replace current version? ") {
-						log.Printf("[*] Stopping current container\n")
+						utils.LogInfo("[*] Stopping current container\n")
 						if manager.GetManager().IsServiceRunning(strings.ToLower(f.Name())) {
 							if err := ServiceStop([]string{f.Name()}); err != nil {
 								log.Printf("[-] Failed to stop current container: %v\n", err)
 								return err
 							}
 						}
-						log.Printf("[*] Removing current version\n")
+						utils.LogInfo("[*] Removing current version\n")
 						err = os.RemoveAll(filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), f.Name()))
 						if err != nil {
 							log.Printf("[-] Failed to remove current version: %v\n", err)
 							log.Printf("[-] Continuing to the next payload\n")
 							continue
 						} else {
-							log.Printf("[+] Successfully removed the current version\n")
+							utils.LogInfo("[+] Successfully removed the current version\n")
 						}
 					} else {
 						log.Printf("[!] Skipping Payload Type, %s\n", f.Name())
 						continue
 					}
 				}
-				log.Printf("[*] Copying new version of payload into place\n")
+				utils.LogInfo("[*] Copying new version of payload into place\n")
 				err = utils.CopyDir(filepath.Join(installPath, "Payload_Type", f.Name()),
 					filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), f.Name()))
 				if err != nil {
 					log.Printf("[-] Failed to copy directory over: %v\n", err)
 					continue
 				}
-				log.Printf("[*] Adding service into docker-compose\n")
+				utils.LogInfo("[*] Adding service into docker-compose\n")
 				if installConfig.IsSet("docker-compose") {
-					err = Add3rdPartyService(f.Name(), installConfig.GetStringMap("docker-compose"), true)
+					err = Install3rdPartyService(f.Name(), installConfig.GetStringMap("docker-compose"), true)
 					if err != nil {
 						log.Printf("[-] Failed to add service to docker-compose: %v\n", err)
 					} else {
-						err = manager.GetManager().BuildServices([]string{f.Name()})
+						err = manager.GetManager().BuildServices([]string{f.Name()}, 0, false)
 						if err != nil {
 							log.Printf("[-] Failed to start service: %v\n", err)
 						}
 					}
 				} else {
-					err = Add3rdPartyService(f.Name(), make(map[string]interface{}), true)
+					err = Install3rdPartyService(f.Name(), make(map[string]interface{}), true)
 					if err != nil {
 						log.Printf("[-] Failed to add service to docker-compose: %v\n", err)
 					} else {
-						err = manager.GetManager().BuildServices([]string{f.Name()})
+						err = manager.GetManager().BuildServices([]string{f.Name()}, 0, false)
 						if err != nil {
 							log.Printf("[-] Failed to start service: %v\n", err)
 						}
@@ -109,9 +110,9 @@ func InstallFolder(installPath string, overWrite bool) error {
 
 			}
 		}
-		log.Printf("[+] Successfully installed service\n")
+		utils.LogInfo("[+] Successfully installed service\n")
 	} else {
-		log.Printf("[*] Skipping over Payload Type\n")
+		utils.LogInfo("[*] Skipping over Payload Type\n")
 	}
 	if !installConfig.GetBool("exclude_c2_profiles") {
 		// handle the c2 profile copying here
@@ -122,31 +123,31 @@ func InstallFolder(installPath string, overWrite bool) error {
 		}
 		for _, f := range files {
 			if f.IsDir() {
-				log.Printf("[*] Processing C2 Profile %s\n", f.Name())
+				utils.LogInfo("[*] Processing C2 Profile %s\n", f.Name())
 				if utils.DirExists(filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), f.Name())) {
 					if overWrite || config.AskConfirm("[*] "+f.Name()+" already exists. Replace current version? ") {
-						log.Printf("[*] Stopping current container\n")
+						utils.LogInfo("[*] Stopping current container\n")
 						if manager.GetManager().IsServiceRunning(strings.ToLower(f.Name())) {
 							if err := ServiceStop([]string{f.Name()}); err != nil {
 								log.Printf("[-] Failed to stop container: %v\n", err)
 								return err
 							}
 						}
-						log.Printf("[*] Removing current version\n")
+						utils.LogInfo("[*] Removing current version\n")
 						err = os.RemoveAll(filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), f.Name()))
 						if err != nil {
 							log.Printf("[-] Failed to remove current version: %v\n", err)
 							log.Printf("[-] Continuing to the next c2 profile\n")
 							continue
 						} else {
-							log.Printf("[+] Successfully removed the current version\n")
+							utils.LogInfo("[+] Successfully removed the current version\n")
 						}
 					} else {
 						log.Printf("[!] Skipping C2 Profile, %s\n", f.Name())
 						continue
 					}
 				}
-				log.Printf("[*] Copying new version into place\n")
+				utils.LogInfo("[*] Copying new version into place\n")
 				err = utils.CopyDir(filepath.Join(installPath, "C2_Profiles", f.Name()),
 					filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), f.Name()))
 				if err != nil {
@@ -154,21 +155,21 @@ func InstallFolder(installPath string, overWrite bool) error {
 					continue
 				}
 				// now add payload type to yaml installConfig
-				log.Printf("[*] Adding c2, %s, into docker-compose\n", f.Name())
-				err = Add3rdPartyService(f.Name(), make(map[string]interface{}), true)
+				utils.LogInfo("[*] Adding c2, %s, into docker-compose\n", f.Name())
+				err = Install3rdPartyService(f.Name(), make(map[string]interface{}), true)
 				if err != nil {
 					log.Printf("[-] Failed to add %s to docker-compose: %v\n", f.Name(), err)
 				} else {
-					err = manager.GetManager().BuildServices([]string{f.Name()})
+					err = manager.GetManager().BuildServices([]string{f.Name()}, 0, false)
 					if err != nil {
 						log.Printf("[-] Failed to start service: %v\n", err)
 					}
 				}
 			}
 		}
-		log.Printf("[+] Successfully installed c2\n")
+		utils.LogInfo("[+] Successfully installed c2\n")
 	} else {
-		log.Printf("[*] Skipping over C2 Profile\n")
+		utils.LogInfo("[*] Skipping over C2 Profile\n")
 	}
 	if !installConfig.GetBool("exclude_documentation_payload") {
 		// handle payload documentation copying here
@@ -178,25 +179,25 @@ func InstallFolder(installPath string, overWrite bool) error {
 		} else {
 			for _, f := range files {
 				if f.IsDir() {
-					log.Printf("[*] Processing Documentation for %s\n", f.Name())
+					utils.LogInfo("[*] Processing Documentation for %s\n", f.Name())
 					if !config.GetMythicEnv().GetBool("documentation_use_volume") {
 						if utils.DirExists(filepath.Join(workingPath, "documentation-docker", "content", "Agents", f.Name())) {
 							if overWrite || config.AskConfirm("[*] "+f.Name()+" documentation already exists. Replace current version? ") {
-								log.Printf("[*] Removing current version\n")
+								utils.LogInfo("[*] Removing current version\n")
 								err = os.RemoveAll(filepath.Join(workingPath, "documentation-docker", "content", "Agents", f.Name()))
 								if err != nil {
 									log.Printf("[-] Failed to remove current version: %v\n", err)
 									log.Printf("[-] Continuing to the next payload documentation\n")
 									continue
 								} else {
-									log.Printf("[+] Successfully removed the current version\n")
+									utils.LogInfo("[+] Successfully removed the current version\n")
 								}
 							} else {
 								log.Printf("[!] Skipping documentation for , %s\n", f.Name())
 								continue
 							}
 						}
-						log.Printf("[*] Copying new documentation into place\n")
+						utils.LogInfo("[*] Copying new documentation into place\n")
 						err = utils.CopyDir(filepath.Join(installPath, "documentation-payload", f.Name()), filepath.Join(workingPath, "documentation-docker", "content", "Agents", f.Name()))
 						if err != nil {
 							log.Printf("[-] Failed to copy directory over\n")
@@ -214,11 +215,11 @@ func InstallFolder(installPath string, overWrite bool) error {
 
 				}
 			}
-			log.Printf("[+] Successfully installed Payload documentation\n")
+			utils.LogInfo("[+] Successfully installed Payload documentation\n")
 		}
 
 	} else {
-		log.Printf("[*] Skipping over Payload Documentation\n")
+		utils.LogInfo("[*] Skipping over Payload Documentation\n")
 	}
 	if !installConfig.GetBool("exclude_documentation_c2") {
 		// handle the c2 documentation copying here
@@ -228,25 +229,25 @@ func InstallFolder(installPath string, overWrite bool) error {
 		} else {
 			for _, f := range files {
 				if f.IsDir() {
-					log.Printf("[*] Processing Documentation for %s\n", f.Name())
+					utils.LogInfo("[*] Processing Documentation for %s\n", f.Name())
 					if !config.GetMythicEnv().GetBool("mythic_documentation_use_volume") {
 						if utils.DirExists(filepath.Join(workingPath, "documentation-docker", "content", "C2 Profiles", f.Name())) {
 							if overWrite || config.AskConfirm("[*] "+f.Name()+" documentation already exists. Replace current version? ") {
-								log.Printf("[*] Removing current version\n")
+								utils.LogInfo("[*] Removing current version\n")
 								err = os.RemoveAll(filepath.Join(workingPath, "documentation-docker", "content", "C2 Profiles", f.Name()))
 								if err != nil {
 									log.Printf("[-] Failed to remove current version: %v\n", err)
 									log.Printf("[-] Continuing to the next c2 documentation\n")
 									continue
 								} else {
-									log.Printf("[+] Successfully removed the current version\n")
+									utils.LogInfo("[+] Successfully removed the current version\n")
 								}
 							} else {
 								log.Printf("[!] Skipping documentation for %s\n", f.Name())
 								continue
 							}
 						}
-						log.Printf("[*] Copying new documentation version into place\n")
+						utils.LogInfo("[*] Copying new documentation version into place\n")
 						err = utils.CopyDir(filepath.Join(installPath, "documentation-c2", f.Name()),
 							filepath.Join(workingPath, "documentation-docker", "content", "C2 Profiles", f.Name()))
 						if err != nil {
@@ -266,11 +267,11 @@ func InstallFolder(installPath string, overWrite bool) error {
 
 				}
 			}
-			log.Printf("[+] Successfully installed c2 documentation\n")
+			utils.LogInfo("[+] Successfully installed c2 documentation\n")
 		}
 
 	} else {
-		log.Printf("[*] Skipping over C2 Documentation\n")
+		utils.LogInfo("[*] Skipping over C2 Documentation\n")
 	}
 	if !installConfig.GetBool("exclude_documentation_wrapper") {
 		// handle payload documentation copying here
@@ -280,25 +281,25 @@ func InstallFolder(installPath string, overWrite bool) error {
 		} else {
 			for _, f := range files {
 				if f.IsDir() {
-					log.Printf("[*] Processing Documentation for %s\n", f.Name())
+					utils.LogInfo("[*] Processing Documentation for %s\n", f.Name())
 					if !config.GetMythicEnv().GetBool("mythic_documentation_use_volume") {
 						if utils.DirExists(filepath.Join(workingPath, "documentation-docker", "content", "Wrappers", f.Name())) {
 							if overWrite || config.AskConfirm("[*] "+f.Name()+" documentation already exists. Replace current version? ") {
-								log.Printf("[*] Removing current version\n")
+								utils.LogInfo("[*] Removing current version\n")
 								err = os.RemoveAll(filepath.Join(workingPath, "documentation-docker", "content", "Wrappers", f.Name()))
 								if err != nil {
 									log.Printf("[-] Failed to remove current version: %v\n", err)
 									log.Printf("[-] Continuing to the next wrapper documentation\n")
 									continue
 								} else {
-									log.Printf("[+] Successfully removed the current version\n")
+									utils.LogInfo("[+] Successfully removed the current version\n")
 								}
 							} else {
 								log.Printf("[!] Skipping documentation for , %s\n", f.Name())
 								continue
 							}
 						}
-						log.Printf("[*] Copying new documentation into place\n")
+						utils.LogInfo("[*] Copying new documentation into place\n")
 						err = utils.CopyDir(filepath.Join(installPath, "documentation-wrapper", f.Name()),
 							filepath.Join(workingPath, "documentation-docker", "content", "Wrappers", f.Name()))
 						if err != nil {
@@ -316,10 +317,10 @@ func InstallFolder(installPath string, overWrite bool) error {
 					}
 				}
 			}
-			log.Printf("[+] Successfully installed Wrapper documentation\n")
+			utils.LogInfo("[+] Successfully installed Wrapper documentation\n")
 		}
 	} else {
-		log.Printf("[*] Skipping over Wrapper Documentation\n")
+		utils.LogInfo("[*] Skipping over Wrapper Documentation\n")
 	}
 
 	if manager.GetManager().IsServiceRunning("mythic_documentation") {
@@ -329,10 +330,19 @@ func InstallFolder(installPath string, overWrite bool) error {
 	}
 	return nil
 }
-func InstallService(url string, branch string, overWrite bool) error {
+
+// InstallService clones url at branch (a branch or tag) and installs the services in it.
+// When subPath is set, only that folder of the repository is installed, for repositories that hold more than one service folder.
+func InstallService(url string, branch string, subPath string, overWrite bool) error {
+	if subPath != "" {
+		subPath = filepath.Clean(subPath)
+		if filepath.IsAbs(subPath) || subPath == ".." || strings.HasPrefix(subPath, ".."+string(filepath.Separator)) {
+			return errors.New(fmt.Sprintf("%s needs to be a relative path inside the repository", subPath))
+		}
+	}
 	// make our temp directory to clone into
 	workingPath := utils.GetCwdFromExe()
-	log.Printf("[*] Creating temporary directory\n")
+	utils.LogVerbose("[*] Creating temporary directory\n")
 	if utils.DirExists(filepath.Join(workingPath, "tmp")) {
 		err := os.RemoveAll(filepath.Join(workingPath, "tmp"))
 		if err != nil {
@@ -347,20 +357,26 @@ func InstallService(url string, branch string, overWrite bool) error {
 		return err
 	}
 	if branch == "" {
-		log.Printf("[*] Cloning %s\n", url)
+		utils.LogInfo("[*] Cloning %s\n", url)
 		err = runGitClone([]string{"-c", "http.sslVerify=false", "clone", "--recurse-submodules", "--single-branch", url, filepath.Join(workingPath, "tmp")})
 	} else {
-		log.Printf("[*] Cloning branch \"%s\" from %s\n", branch, url)
+		utils.LogInfo("[*] Cloning branch \"%s\" from %s\n", branch, url)
 		err = runGitClone([]string{"-c", "http.sslVerify=false", "clone", "--recurse-submodules", "--single-branch", "--branch", branch, url, filepath.Join(workingPath, "tmp")})
 	}
 	if err != nil {
 		log.Printf("[-] Failed to clone down repository: %v\n", err)
 		return err
 	}
-	if err = InstallFolder(filepath.Join(workingPath, "tmp"), overWrite); err != nil {
+	installPath := filepath.Join(workingPath, "tmp", subPath)
+	if !utils.DirExists(installPath) {
+		log.Printf("[-] %s doesn't exist in the repository\n", subPath)
+		return errors.New(fmt.Sprintf("failed to find %s in %s", subPath, url))
+	}
+	if err = InstallFolder(installPath, overWrite); err != nil {
 		log.Printf("[-] Failed to install: %v\n", err)
 		return err
 	} else {
+		recordInstallSource(installPath, url, branch, subPath)
 		return nil
 	}
 }
@@ -374,11 +390,11 @@ func installServiceByName(service string) error {
 			return err
 		} else {
 			// this exists as a c2 profile repo, so we can pull that down
-			return InstallService(c2URL, "", true)
+			return InstallService(c2URL, "", "", true)
 		}
 	} else {
 		// this exists as an agent repo, so we can pull that down
-		return InstallService(agentURL, "", true)
+		return InstallService(agentURL, "", "", true)
 	}
 }
 func UninstallService(services []string) {
@@ -389,60 +405,64 @@ func UninstallService(services []string) {
 		}
 		found := false
 		if utils.DirExists(filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), service)) {
-			log.Printf("[*] Stopping and removing container\n")
+			utils.LogInfo("[*] Stopping and removing container\n")
 			if manager.GetManager().IsServiceRunning(strings.ToLower(service)) {
 				if err := ServiceStop([]string{strings.ToLower(service)}); err != nil {
 					log.Printf("[-] Failed to stop container: %v\n", err)
 					return
 				}
 			}
-			log.Printf("[*] Removing %s from docker-compose\n", strings.ToLower(service))
+			utils.LogInfo("[*] Removing %s from docker-compose\n", strings.ToLower(service))
 			err := manager.GetManager().RemoveServices([]string{strings.ToLower(service)})
 			if err != nil {
 				log.Printf("[-] Failed to remove docker compose entry: %v\n", err)
 				return
 			}
-			log.Printf("[*] Removing Payload Type folder from disk\n")
+			utils.LogInfo("[*] Removing %s's volumes\n", strings.ToLower(service))
+			if err = manager.GetManager().RemoveServiceVolumes(service); err != nil {
+				log.Printf("[-] Failed to remove volumes, remove them with './mythic-cli volume rm': %v\n", err)
+			}
+			utils.LogInfo("[*] Removing Payload Type folder from disk\n")
 			found = true
 			err = os.RemoveAll(filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), service))
 			if err != nil {
 				log.Fatalf("[-] Failed to remove folder: %v\n", err)
 			}
-			log.Printf("[+] Successfully removed %s's folder\n", service)
+			utils.LogInfo("[+] Successfully removed %s's folder\n", service)
 
 			if utils.DirExists(filepath.Join(workingPath, "documentation-docker", "content", "Agents", service)) {
-				log.Printf("[*] Removing Payload Type's Documentation from disk\n")
+				utils.LogInfo("[*] Removing Payload Type's Documentation from disk\n")
 				err = os.RemoveAll(filepath.Join(workingPath, "documentation-docker", "content", "Agents", service))
 				if err != nil {
 					log.Fatalf("[-] Failed to remove Payload Type's Documentation: %v\n", err)
 				}
-				log.Printf("[+] Successfully removed Payload Type's Documentation\n")
+				utils.LogInfo("[+] Successfully removed Payload Type's Documentation\n")
 
 			}
 			if utils.DirExists(filepath.Join(workingPath, "documentation-docker", "content", "C2 Profiles", service)) {
-				log.Printf("[*] Removing C2 Profile's Documentation\n")
+				utils.LogInfo("[*] Removing C2 Profile's Documentation\n")
 				err = os.RemoveAll(filepath.Join(workingPath, "documentation-docker", "content", "C2 Profiles", service))
 				if err != nil {
 					log.Fatalf("[-] Failed to remove C2 Profile's Documentation: %v\n", err)
 				}
-				log.Printf("[+] Successfully removed C2 Profile's Documentation\n")
+				utils.LogInfo("[+] Successfully removed C2 Profile's Documentation\n")
 
 			}
 			if utils.DirExists(filepath.Join(workingPath, "documentation-docker", "content", "Wrappers", service)) {
-				log.Printf("[*] Removing C2 Profile's Documentation\n")
+				utils.LogInfo("[*] Removing C2 Profile's Documentation\n")
 				err = os.RemoveAll(filepath.Join(workingPath, "documentation-docker", "content", "Wrappers", service))
 				if err != nil {
 					log.Fatalf("[-] Failed to remove C2 Profile's Documentation: %v\n", err)
 				}
-				log.Printf("[+] Successfully removed C2 Profile's Documentation\n")
+				utils.LogInfo("[+] Successfully removed C2 Profile's Documentation\n")
 
 			}
 		}
 
 		if found {
-			log.Printf("[+] Successfully Uninstalled %s\n", service)
+			utils.LogInfo("[+] Successfully Uninstalled %s\n", service)
 			if manager.GetManager().IsServiceRunning("mythic_documentation") {
-				log.Printf("[*] Restarting mythic_documentation container to pull in changes\n")
+				utils.LogInfo("[*] Restarting mythic_documentation container to pull in changes\n")
 				ServiceStop([]string{"mythic_documentation"})
 				ServiceStart([]string{"mythic_documentation"})
 			}
@@ -477,10 +497,10 @@ func InstallMythicSyncFolder(installPath string) error {
 		log.Printf("[-] Failed to create %s directory to install mythic_sync: %v\n", service, err)
 		return err
 	}
-	AddMythicService(service, true)
-	log.Printf("[+] Successfully installed mythic_sync!\n")
+	InstallMythicService(service, true)
+	utils.LogInfo("[+] Successfully installed mythic_sync!\n")
 	if manager.GetManager().IsServiceRunning("mythic_server") {
-		log.Printf("[*] Starting mythic_sync")
+		utils.LogInfo("[*] Starting mythic_sync")
 		err = ServiceStart([]string{strings.ToLower(service)})
 		if err != nil {
 			log.Printf("[-] Failed to start mythic_sync: %v\n", err)
@@ -491,7 +511,7 @@ func InstallMythicSyncFolder(installPath string) error {
 func InstallMythicSync(url string, branch string) error {
 	// make our temp directory to clone into
 	workingPath := utils.GetCwdFromExe()
-	log.Printf("[*] Creating temporary directory\n")
+	utils.LogVerbose("[*] Creating temporary directory\n")
 	if utils.DirExists(filepath.Join(workingPath, "tmp")) {
 		if err := os.RemoveAll(filepath.Join(workingPath, "tmp")); err != nil {
 			log.Printf("[-] %s directory couldn't be deleted for a fresh install: %v\n", filepath.Join(workingPath, "tmp"), err)
@@ -504,10 +524,10 @@ func InstallMythicSync(url string, branch string) error {
 		log.Fatalf("[-] Failed to make temp directory for cloning: %v\n", err)
 	}
 	if branch == "" {
-		log.Printf("[*] Cloning %s\n", url)
+		utils.LogInfo("[*] Cloning %s\n", url)
 		err = runGitClone([]string{"-c", "http.sslVerify=false", "clone", "--depth", "1", "--recurse-submodules", "--single-branch", url, filepath.Join(workingPath, "tmp")})
 	} else {
-		log.Printf("[*] Cloning branch \"%s\" from %s\n", branch, url)
+		utils.LogInfo("[*] Cloning branch \"%s\" from %s\n", branch, url)
 		err = runGitClone([]string{"-c", "http.sslVerify=false", "clone", "--depth", "1", "--recurse-submodules", "--single-branch", "--branch", branch, url, filepath.Join(workingPath, "tmp")})
 	}
 	if err != nil {
@@ -528,11 +548,11 @@ func UninstallMythicSync() {
 		if err != nil {
 			log.Fatalf("[-] %s directory couldn't be deleted: %v\n", service, err)
 		} else {
-			log.Printf("[+] Successfully removed %s from disk\n", service)
+			utils.LogInfo("[+] Successfully removed %s from disk\n", service)
 			return
 		}
 	} else {
-		log.Printf("[+] %s was not installed on disk\n", service)
+		utils.LogInfo("[+] %s was not installed on disk\n", service)
 		return
 	}
 }
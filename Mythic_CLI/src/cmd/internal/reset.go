@@ -3,25 +3,26 @@ package internal
 import (
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
 	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
+	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
 	"log"
 )
 
 func DatabaseReset(force bool) {
 	if force {
-		log.Printf("[*] Stopping Mythic\n")
+		utils.LogInfo("[*] Stopping Mythic\n")
 		manager.GetManager().StopServices([]string{}, config.GetMythicEnv().GetBool("REBUILD_ON_START"))
 		manager.GetManager().ResetDatabase(config.GetMythicEnv().GetBool("postgres_use_volume"))
-		log.Printf("[*] Removing database files\n")
+		utils.LogInfo("[*] Removing database files\n")
 		return
 	}
 	confirm := config.AskConfirm("Are you sure you want to reset the database? ")
 	if confirm {
 		confirm = config.AskConfirm("Are you absolutely sure? This will delete ALL data with your database forever. ")
 		if confirm {
-			log.Printf("[*] Stopping Mythic\n")
+			utils.LogInfo("[*] Stopping Mythic\n")
 			manager.GetManager().StopServices([]string{}, config.GetMythicEnv().GetBool("REBUILD_ON_START"))
 			manager.GetManager().ResetDatabase(config.GetMythicEnv().GetBool("postgres_use_volume"))
-			log.Printf("[*] Removing database files\n")
+			utils.LogInfo("[*] Removing database files\n")
 		}
 	}
 }
@@ -74,3 +75,12 @@ func FilesRestore(backupPath string) {
 		}
 	}
 }
+func ComposeRestore() {
+	confirm := config.AskConfirm("Are you sure you want to swap docker-compose.yml with docker-compose.yml.bak? ")
+	if confirm {
+		if err := manager.GetManager().RestoreComposeBackup(); err != nil {
+			log.Fatalf("[-] Failed to restore docker-compose backup: %v\n", err)
+		}
+		utils.LogInfo("[+] Successfully restored docker-compose.yml from backup\n")
+	}
+}
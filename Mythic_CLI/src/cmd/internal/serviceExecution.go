@@ -1,15 +1,26 @@
 package internal
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
 	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
 	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
 	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 )
 
 // ServiceStart is entrypoint from commands to start containers
 func ServiceStart(containers []string) error {
+	// catch bad settings before anything is stopped instead of as a container failure later
+	if err := checkMythicEnv(); err != nil {
+		return err
+	}
 	// first stop all the containers or the ones specified
 	_ = manager.GetManager().StopServices(containers, config.GetMythicEnv().GetBool("REBUILD_ON_START"))
 
@@ -75,8 +86,17 @@ func ServiceStart(containers []string) error {
 		}
 	}
 	manager.GetManager().TestPorts(finalContainers)
+	if err = WriteDockerSecretFiles(); err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to write docker secrets: %v\n", err))
+	}
+	if config.GetMythicEnv().GetBool("use_docker_secrets") && !config.GetMythicEnv().GetBool("installed_service_use_docker_secrets") && len(dockerComposeContainers) > 0 {
+		log.Printf("[!] Installed services still get RABBITMQ_PASSWORD as an environment variable, set installed_service_use_docker_secrets to true if they support RABBITMQ_PASSWORD_FILE\n")
+	}
 	err = manager.GetManager().StartServices(finalContainers, config.GetMythicEnv().GetBool("REBUILD_ON_START"))
-	err = manager.GetManager().RemoveImages()
+	if err != nil {
+		return err
+	}
+	_, err = manager.GetManager().RemoveImages(false)
 	if err != nil {
 		fmt.Printf("[-] Failed to remove images\n%v\n", err)
 		return err
@@ -85,13 +105,84 @@ func ServiceStart(containers []string) error {
 	generateCerts()
 	TestMythicRabbitmqConnection()
 	TestMythicConnection()
-	Status(false)
+	Status(false, "", "")
 	return nil
 }
 func ServiceStop(containers []string) error {
 	return manager.GetManager().StopServices(containers, config.GetMythicEnv().GetBool("REBUILD_ON_START"))
 }
-func ServiceBuild(containers []string) error {
+func StartProfile(name string) error {
+	services, err := getProfileServices(name)
+	if err != nil {
+		return err
+	}
+	return ServiceStart(services)
+}
+func StopProfile(name string) error {
+	services, err := getProfileServices(name)
+	if err != nil {
+		return err
+	}
+	return ServiceStop(services)
+}
+func SaveProfile(name string, services []string) error {
+	if err := config.SaveServiceProfile(name, services); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Saved profile %s with %s\n", name, strings.Join(services, ", "))
+	return nil
+}
+
+// getProfileServices expands a profile into its services, warning about and skipping any that no longer exist
+func getProfileServices(name string) ([]string, error) {
+	profileServices, err := config.GetServiceProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	diskServices, err := manager.GetManager().GetInstalled3rdPartyServicesOnDisk()
+	if err != nil {
+		return nil, err
+	}
+	composeServices, err := manager.GetManager().GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return nil, err
+	}
+	var services []string
+	for _, service := range profileServices {
+		if utils.StringInSlice(service, config.MythicPossibleServices) ||
+			utils.StringInSlice(service, diskServices) ||
+			utils.StringInSlice(service, composeServices) {
+			services = append(services, service)
+		} else {
+			log.Printf("[-] Profile %s contains %s, but it's not installed anymore, skipping it\n", name, service)
+		}
+	}
+	if len(services) == 0 {
+		return nil, errors.New(fmt.Sprintf("none of the services in profile %s exist anymore", name))
+	}
+	return services, nil
+}
+
+// checkMythicEnv prints any problems with the .env settings and returns an error if services can't start with them
+func checkMythicEnv() error {
+	fatal := false
+	for _, issue := range config.ValidateMythicEnv() {
+		if issue.Fatal {
+			fatal = true
+			log.Printf("[-] %s %s\n", issue.Key, issue.Message)
+		} else {
+			log.Printf("[!] %s %s\n", issue.Key, issue.Message)
+		}
+	}
+	if fatal {
+		return errors.New("[-] Fix the settings above with './mythic-cli config set [key] [value]' before starting Mythic\n")
+	}
+	return nil
+}
+
+// ServiceBuild rebuilds and recreates the containers for services.
+// With buildOnly, only the images are built and the running containers (and their volumes) are left alone.
+func ServiceBuild(containers []string, maxParallel int, noCache bool, buildOnly bool) error {
 	composeServices, err := manager.GetManager().GetAllInstalled3rdPartyServiceNames()
 	if err != nil {
 		log.Fatalf("[-] Failed to get installed service list: %v", err)
@@ -99,32 +190,260 @@ func ServiceBuild(containers []string) error {
 	for _, container := range containers {
 		if utils.StringInSlice(container, config.MythicPossibleServices) {
 			// update the necessary docker compose entries for mythic services
-			AddMythicService(container, true)
+			AddMythicService(container, !buildOnly)
 		} else if utils.StringInSlice(container, composeServices) {
-			Add3rdPartyService(container, map[string]interface{}{}, true)
+			Add3rdPartyService(container, map[string]interface{}{}, !buildOnly)
+		}
+	}
+	if buildOnly {
+		if err = manager.GetManager().BuildOnly(containers, noCache); err != nil {
+			return err
 		}
+		utils.LogInfo("[+] Built new images, use './mythic-cli start %s' when you're ready to switch to them\n", strings.Join(containers, " "))
+		return nil
 	}
-	err = manager.GetManager().BuildServices(containers)
+	err = manager.GetManager().BuildServices(containers, maxParallel, noCache)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+func ServiceScale(service string, replicas int) error {
+	if err := manager.GetManager().ScaleService(service, replicas); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Scaled %s to %d instance(s)\n", service, replicas)
+	return nil
+}
+func ServiceLogging(service string, driver string, options map[string]string) error {
+	if err := manager.GetManager().SetServiceLogging(service, driver, options); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Set the %s log driver for %s, restart it to apply\n", driver, service)
+	return nil
+}
+func ServiceHealthcheck(service string, hc manager.Healthcheck) error {
+	if err := manager.GetManager().SetServiceHealthcheck(service, hc); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Set the healthcheck for %s, restart it to apply\n", service)
+	return nil
+}
+func ServicePlatform(service string, platform string) error {
+	if err := manager.GetManager().SetServicePlatform(service, platform); err != nil {
+		return err
+	}
+	if platform == "" {
+		utils.LogInfo("[+] Removed the platform for %s, rebuild it to use the host's architecture\n", service)
+	} else {
+		utils.LogInfo("[+] Set the platform for %s to %s, rebuild it to apply\n", service, platform)
+	}
+	return nil
+}
 func ServiceRemoveContainers(containers []string) error {
 	return manager.GetManager().RemoveContainers(containers)
 }
 
 // Docker Save / Load commands
 
-func DockerSave(containers []string) error {
-	return manager.GetManager().SaveImages(containers, "saved_images")
+func DockerSave(containers []string, separate bool) error {
+	if separate {
+		return withManagerErrorHint(manager.GetManager().SaveImagesSeparately(containers, "saved_images"))
+	}
+	return withManagerErrorHint(manager.GetManager().SaveImages(containers, "saved_images"))
 }
 func DockerLoad() error {
-	return manager.GetManager().LoadImages("saved_images")
+	return withManagerErrorHint(manager.GetManager().LoadImages("saved_images"))
+}
+
+// withManagerErrorHint adds a suggestion for how to fix the known manager failure modes
+func withManagerErrorHint(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, manager.ErrDockerUnavailable):
+		return fmt.Errorf("%w\n[*] Make sure Docker is running and that you have permission to use it (ex: sudo)", err)
+	case errors.Is(err, manager.ErrImageNotFound):
+		return fmt.Errorf("%w\n[*] Use './mythic-cli build' or './mythic-cli pull' to get the images first", err)
+	case errors.Is(err, manager.ErrVolumeNotFound):
+		return fmt.Errorf("%w\n[*] Use './mythic-cli volume ls' to see the available volumes", err)
+	default:
+		return err
+	}
+}
+func DockerPush(registry string, containers []string) error {
+	return manager.GetManager().PushImages(containers, registry)
+}
+func DockerPull(registry string, containers []string) error {
+	return manager.GetManager().PullImages(containers, registry)
+}
+
+// DockerRemoveImages removes dangling images, or Mythic images older than olderThan when it's set
+func DockerRemoveImages(olderThan time.Duration, keepRunning bool, dryRun bool) error {
+	if olderThan > 0 {
+		return manager.GetManager().RemoveImagesOlderThan(olderThan, keepRunning)
+	}
+	_, err := manager.GetManager().RemoveImages(dryRun)
+	return err
+}
+func DockerPrune(includeVolumes bool, includeBuildCache bool, force bool) error {
+	if includeVolumes && !force {
+		if !config.AskConfirm("Are you sure you want to delete all volumes that no longer belong to a service? ") {
+			includeVolumes = false
+		}
+	}
+	return manager.GetManager().Prune(includeVolumes, includeBuildCache)
+}
+func DumpEffectiveConfig(format string, showSecrets bool) error {
+	return manager.GetManager().DumpEffectiveConfig(os.Stdout, format, showSecrets)
+}
+
+// PrintEnvDiff prints only the settings that are different from Mythic's defaults, redacting secrets unless showSecrets is true
+func PrintEnvDiff(jsonOutput bool, showSecrets bool) error {
+	diffs := config.GetEnvDiff()
+	if !showSecrets {
+		for i := range diffs {
+			if config.IsSecretSetting(diffs[i].Key) {
+				if diffs[i].Value != "" {
+					diffs[i].Value = config.RedactedValue
+				}
+				if diffs[i].Default != "" {
+					diffs[i].Default = config.RedactedValue
+				}
+			}
+		}
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to serialize config differences: %v\n", err))
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	if len(diffs) == 0 {
+		utils.LogInfo("[+] All settings are using their default values\n")
+		return nil
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "SETTING\tVALUE\tDEFAULT\tSOURCE")
+	for _, diff := range diffs {
+		defaultValue := diff.Default
+		if !diff.HasDefault {
+			defaultValue = "(not a default setting)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", diff.Key, diff.Value, defaultValue, diff.Source)
+	}
+	return w.Flush()
+}
+func DockerComposeConfig() error {
+	return manager.GetManager().PrintComposeConfig(os.Stdout)
 }
 func DockerHealth(containers []string) {
 	manager.GetManager().GetHealthCheck(containers)
 }
+func DockerOverallHealth() {
+	healthy, details, err := manager.GetManager().OverallHealth()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	services := make([]string, 0, len(details))
+	for service := range details {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "SERVICE\tSTATUS")
+	for _, service := range services {
+		fmt.Fprintf(w, "%s\t%s\n", service, details[service])
+	}
+	w.Flush()
+	if !healthy {
+		log.Printf("[-] Mythic is not healthy\n")
+		os.Exit(1)
+	}
+	utils.LogInfo("[+] Mythic is healthy\n")
+}
+func Doctor(jsonOutput bool) {
+	diagnostics := manager.GetManager().Doctor()
+	failed := false
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Status == manager.DiagnosticFail {
+			failed = true
+		}
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(diagnostics, "", "  ")
+		if err != nil {
+			log.Fatalf("[-] Failed to serialize diagnostics: %v\n", err)
+		}
+		fmt.Println(string(output))
+	} else {
+		w := new(tabwriter.Writer)
+		w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+		fmt.Fprintln(w, "CHECK\tSTATUS\tDETAILS")
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", diagnostic.Name, strings.ToUpper(string(diagnostic.Status)), diagnostic.Message)
+			if diagnostic.Hint != "" && diagnostic.Status != manager.DiagnosticPass {
+				fmt.Fprintf(w, "\t\t-> %s\n", diagnostic.Hint)
+			}
+		}
+		w.Flush()
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// InspectService prints the curated details for a service's container, or the raw inspect output as JSON
+func InspectService(service string, jsonOutput bool) error {
+	inspect, err := manager.GetManager().InspectService(service)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(inspect.Raw, "", "  ")
+		if err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to serialize inspect output: %v\n", err))
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintf(w, "Service\t%s\n", inspect.Service)
+	fmt.Fprintf(w, "Container\t%s\n", inspect.ContainerID)
+	fmt.Fprintf(w, "Image\t%s\n", inspect.Image)
+	fmt.Fprintf(w, "State\t%s\n", inspect.State)
+	fmt.Fprintf(w, "Health\t%s\n", inspect.Health)
+	fmt.Fprintf(w, "Restart Policy\t%s\n", inspect.RestartPolicy)
+	cpus := "unlimited"
+	if inspect.CPUs > 0 {
+		cpus = fmt.Sprintf("%.2f", inspect.CPUs)
+	}
+	memory := "unlimited"
+	if inspect.MemoryMB > 0 {
+		memory = fmt.Sprintf("%d MB", inspect.MemoryMB)
+	}
+	fmt.Fprintf(w, "CPUs\t%s\n", cpus)
+	fmt.Fprintf(w, "Memory\t%s\n", memory)
+	fmt.Fprintf(w, "Ports\t%s\n", strings.Join(inspect.Ports, ", "))
+	fmt.Fprintln(w, "Mounts\t")
+	for _, mount := range inspect.Mounts {
+		mode := "rw"
+		if mount.ReadOnly {
+			mode = "ro"
+		}
+		fmt.Fprintf(w, "\t%s %s -> %s (%s)\n", mount.Type, mount.Source, mount.Destination, mode)
+	}
+	fmt.Fprintln(w, "Environment\t")
+	for _, env := range inspect.Env {
+		fmt.Fprintf(w, "\t%s\n", env)
+	}
+	return w.Flush()
+}
 
 // Build new Docker UI
 
@@ -136,13 +455,300 @@ func DockerBuildReactUI() error {
 	return nil
 }
 
+// CleanupOrphanedContainers lists the containers from services that no longer exist and removes them after confirmation
+func CleanupOrphanedContainers(force bool) error {
+	orphans, err := manager.GetManager().GetOrphanedContainers()
+	if err != nil {
+		return withManagerErrorHint(err)
+	}
+	if len(orphans) == 0 {
+		utils.LogInfo("[*] No orphaned containers found\n")
+		return nil
+	}
+	// always shown, even with --quiet, since this is what the confirmation is about
+	log.Printf("[*] Found containers for services that aren't Mythic services, in docker-compose, or installed:\n")
+	for _, orphan := range orphans {
+		log.Printf("    %s\n", orphan)
+	}
+	if !force && !config.AskConfirm("Are you sure you want to remove these containers? ") {
+		return errors.New("container removal cancelled")
+	}
+	return withManagerErrorHint(manager.GetManager().RemoveOrphanedContainers())
+}
+
+// OrphansReport is everything FindOrphans and GetOrphanedContainers found
+type OrphansReport struct {
+	Containers []string `json:"containers"`
+	Volumes    []string `json:"volumes"`
+	Images     []string `json:"images"`
+	Folders    []string `json:"folders"`
+}
+
+// PrintOrphans reports the containers, volumes, images, and folders left behind by services that aren't in docker-compose
+func PrintOrphans(jsonOutput bool) error {
+	containers, err := manager.GetManager().GetOrphanedContainers()
+	if err != nil {
+		return withManagerErrorHint(err)
+	}
+	volumes, images, folders, err := manager.GetManager().FindOrphans()
+	if err != nil {
+		return withManagerErrorHint(err)
+	}
+	report := OrphansReport{Containers: containers, Volumes: volumes, Images: images, Folders: folders}
+	if jsonOutput {
+		output, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to serialize orphans: %v\n", err))
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	sections := []struct {
+		name    string
+		items   []string
+		cleanup string
+	}{
+		{"containers", report.Containers, "./mythic-cli cleanup"},
+		{"volumes", report.Volumes, "./mythic-cli volume rm [name]"},
+		{"images", report.Images, "docker image rm [image]"},
+		{"installed service folders", report.Folders, "./mythic-cli add [name] to use them or ./mythic-cli uninstall [name]"},
+	}
+	found := false
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		found = true
+		fmt.Printf("[*] Orphaned %s, clean up with '%s':\n", section.name, section.cleanup)
+		for _, item := range section.items {
+			fmt.Printf("    %s\n", item)
+		}
+	}
+	if !found {
+		fmt.Printf("[+] Nothing is orphaned\n")
+	}
+	return nil
+}
+
+// PrintMissingImages lists the services whose images still need to be built or pulled and returns if there are any
+func PrintMissingImages(jsonOutput bool) (bool, error) {
+	missing, err := manager.GetManager().MissingImages()
+	if err != nil {
+		return false, withManagerErrorHint(err)
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(missing, "", "  ")
+		if err != nil {
+			return false, errors.New(fmt.Sprintf("[-] Failed to serialize missing images: %v\n", err))
+		}
+		fmt.Println(string(output))
+		return len(missing) > 0, nil
+	}
+	if len(missing) == 0 {
+		fmt.Printf("[+] Every service has an image\n")
+		return false, nil
+	}
+	var needBuild, needPull []manager.MissingImage
+	for _, missingImage := range missing {
+		if missingImage.NeedsBuild {
+			needBuild = append(needBuild, missingImage)
+		} else {
+			needPull = append(needPull, missingImage)
+		}
+	}
+	if len(needBuild) > 0 {
+		fmt.Printf("[*] Services that still need to be built, build them with './mythic-cli build [name]':\n")
+		for _, missingImage := range needBuild {
+			fmt.Printf("    %s\n", missingImage.Service)
+		}
+	}
+	if len(needPull) > 0 {
+		fmt.Printf("[*] Services whose images haven't been pulled yet, './mythic-cli start [name]' pulls them:\n")
+		for _, missingImage := range needPull {
+			fmt.Printf("    %s (%s)\n", missingImage.Service, missingImage.Image)
+		}
+	}
+	return true, nil
+}
+
 // Docker Volume commands
 
-func VolumesList() {
-	manager.GetManager().PrintVolumeInformation()
+func VolumesList(jsonOutput bool) {
+	if !jsonOutput {
+		manager.GetManager().PrintVolumeInformation()
+		return
+	}
+	volumeInfo, err := manager.GetManager().GetVolumeInformation()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	output, err := json.MarshalIndent(volumeInfo, "", "  ")
+	if err != nil {
+		log.Fatalf("[-] Failed to serialize volume information: %v\n", err)
+	}
+	fmt.Println(string(output))
+}
+
+// ServiceDiskUsage prints how much disk each service's image, container, and volumes use, largest first
+func ServiceDiskUsage(jsonOutput bool) error {
+	usage, err := manager.GetManager().GetServiceDiskUsage()
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(usage, "", "  ")
+		if err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to serialize disk usage: %v\n", err))
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "SERVICE\tIMAGE\tCONTAINER\tVOLUMES\tTOTAL")
+	for _, info := range usage {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			info.Service,
+			utils.ByteCountSI(info.ImageBytes),
+			utils.ByteCountSI(info.ContainerBytes),
+			utils.ByteCountSI(info.VolumeBytes),
+			utils.ByteCountSI(info.TotalBytes),
+		)
+	}
+	return w.Flush()
+}
+
+// Docker Network commands
+
+func NetworksList(jsonOutput bool) {
+	networks, err := manager.GetManager().GetNetworks()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(networks, "", "  ")
+		if err != nil {
+			log.Fatalf("[-] Failed to serialize network information: %v\n", err)
+		}
+		fmt.Println(string(output))
+		return
+	}
+	if len(networks) == 0 {
+		utils.LogInfo("[*] No networks are configured, services use docker compose's default network\n")
+		return
+	}
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "NETWORK\tCONFIGURATION")
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%v\n", name, networks[name])
+	}
+	w.Flush()
 }
-func DockerRemoveVolume(volumeName string) error {
-	return manager.GetManager().RemoveVolume(volumeName)
+func NetworkAttach(service string, network string) error {
+	if err := manager.GetManager().AttachServiceToNetwork(service, network); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Attached %s to %s, restart %s to apply it\n", service, network, service)
+	return nil
+}
+func DockerRemoveVolume(volumeName string, force bool) error {
+	return withManagerErrorHint(manager.GetManager().RemoveVolume(volumeName, force))
+}
+func DockerRemoveVolumes(volumeNames []string, force bool) error {
+	if !force && !config.AskConfirm(fmt.Sprintf("Are you sure you want to delete %s, all of their contents, and any containers using them? ", strings.Join(volumeNames, ", "))) {
+		return errors.New("volume removal cancelled")
+	}
+	return withManagerErrorHint(manager.GetManager().RemoveVolumes(volumeNames))
+}
+
+func DockerSnapshotVolume(volumeName string) (string, error) {
+	snapshotID, err := manager.GetManager().SnapshotVolume(volumeName)
+	return snapshotID, withManagerErrorHint(err)
+}
+func DockerRestoreVolumeSnapshot(volumeName string, snapshotID string) error {
+	return withManagerErrorHint(manager.GetManager().RestoreVolumeSnapshot(volumeName, snapshotID))
+}
+func DockerCloneVolume(sourceVolume string, destinationVolume string) error {
+	return withManagerErrorHint(manager.GetManager().CloneVolume(sourceVolume, destinationVolume))
+}
+
+// VolumeSnapshotsList prints the saved volume snapshots with their sizes and when they were taken
+func VolumeSnapshotsList(jsonOutput bool) error {
+	snapshots, err := manager.GetManager().ListVolumeSnapshots()
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(snapshots, "", "  ")
+		if err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to serialize volume snapshots: %v\n", err))
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "VOLUME\tSNAPSHOT\tSIZE\tCREATED")
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			snapshot.Volume,
+			snapshot.ID,
+			utils.ByteCountSI(snapshot.Size),
+			snapshot.Created.Format("2006-01-02 15:04:05"),
+		)
+	}
+	return w.Flush()
+}
+
+// DiffComposeFiles prints the differences between two docker-compose files
+func DiffComposeFiles(pathA string, pathB string) error {
+	diff, err := manager.GetManager().DiffComposeFiles(pathA, pathB)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		utils.LogInfo("[+] %s and %s are the same\n", pathA, pathB)
+		return nil
+	}
+	fmt.Print(diff)
+	return nil
+}
+
+// PublishedPortsList prints every port the services in docker-compose publish on the host
+func PublishedPortsList(jsonOutput bool) error {
+	ports, err := manager.GetManager().GetPublishedPorts()
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(ports, "", "  ")
+		if err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to serialize published ports: %v\n", err))
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "SERVICE\tBIND ADDRESS\tHOST PORT\tCONTAINER PORT\tPROTOCOL")
+	for _, port := range ports {
+		if port.HostNetwork {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", port.Service, port.BindAddress, "host network", "-", "-")
+			continue
+		}
+		hostPort := port.HostPort
+		if hostPort == "" {
+			hostPort = "random"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", port.Service, port.BindAddress, hostPort, port.ContainerPort, port.Protocol)
+	}
+	return w.Flush()
 }
 
 func DockerCopyIntoVolume(sourceFile string, destinationFileName string, destinationVolume string) {
@@ -151,3 +757,88 @@ func DockerCopyIntoVolume(sourceFile string, destinationFileName string, destina
 func DockerCopyFromVolume(sourceVolumeName string, sourceFileName string, destinationName string) {
 	manager.GetManager().CopyFromVolume(sourceVolumeName, sourceFileName, destinationName)
 }
+
+// ServiceCopy copies between local disk and a service's container, one of source or destination must be in the form service:/path
+func ServiceCopy(source string, destination string) error {
+	sourceService, sourcePath, sourceInContainer := splitServicePath(source)
+	destinationService, destinationPath, destinationInContainer := splitServicePath(destination)
+	switch {
+	case sourceInContainer && !destinationInContainer:
+		return manager.GetManager().CopyFromContainer(sourceService, sourcePath, destination)
+	case !sourceInContainer && destinationInContainer:
+		return manager.GetManager().CopyIntoContainer(destinationService, source, destinationPath)
+	default:
+		return errors.New("[-] Exactly one of the source or destination needs to be in the form service:/path\n")
+	}
+}
+
+// ShellIntoService opens an interactive shell in a running service
+func ShellIntoService(service string) error {
+	return manager.GetManager().ShellIntoService(service)
+}
+
+// GetServicePort returns the host port a service is published on
+func GetServicePort(service string) (uint16, error) {
+	return manager.GetManager().GetServicePort(service)
+}
+
+// PrintContainerEnv prints the environment variables a service's container is running with, secrets are redacted
+func PrintContainerEnv(service string, jsonOutput bool) error {
+	env, err := manager.GetManager().GetContainerEnv(service)
+	if err != nil {
+		return withManagerErrorHint(err)
+	}
+	if jsonOutput {
+		output, err := json.MarshalIndent(env, "", "  ")
+		if err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to serialize environment: %v\n", err))
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Printf("%s=%s\n", key, env[key])
+	}
+	return nil
+}
+func ServiceEnvSet(service string, entry string) error {
+	key, value, found := strings.Cut(entry, "=")
+	if !found || key == "" {
+		return errors.New(fmt.Sprintf("[-] Environment variables must be in the form KEY=VALUE: %s\n", entry))
+	}
+	if err := manager.GetManager().SetServiceEnvOverride(service, key, value); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Set %s for %s, restart it to apply\n", key, service)
+	return nil
+}
+func ServiceEnvUnset(service string, key string) error {
+	if err := manager.GetManager().RemoveServiceEnvOverride(service, key); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Removed the override for %s from %s, restart it to apply\n", key, service)
+	return nil
+}
+
+// WaitForPort waits for a service to accept connections on its published port
+func WaitForPort(service string, timeout time.Duration) error {
+	if err := manager.GetManager().WaitForPort(service, timeout); err != nil {
+		return withManagerErrorHint(err)
+	}
+	utils.LogInfo("[+] %s is accepting connections\n", service)
+	return nil
+}
+
+// splitServicePath splits service:/path into its service and path, local paths (including ones like ./a:b) aren't split
+func splitServicePath(value string) (string, string, bool) {
+	service, servicePath, found := strings.Cut(value, ":")
+	if !found || service == "" || strings.ContainsAny(service, "/\\.") {
+		return "", "", false
+	}
+	return service, servicePath, true
+}
@@ -1,20 +1,60 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
 	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
 	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
 	"log"
+	"os"
 	"path/filepath"
 	"strings"
 )
 
+// ResetServiceConfiguration makes the add and install flows overwrite a service's entire docker-compose entry instead of merging into it
+var ResetServiceConfiguration = false
+
+// setServiceConfiguration writes a freshly built service configuration for the add and install flows.
+// Only the CLI managed keys are merged into an existing entry unless ResetServiceConfiguration is set.
+func setServiceConfiguration(service string, pStruct map[string]interface{}) error {
+	if ResetServiceConfiguration {
+		return manager.GetManager().SetServiceConfiguration(service, pStruct)
+	}
+	return manager.GetManager().MergeServiceConfiguration(service, pStruct)
+}
+
+// getDefaultServiceLogging builds the logging block for services that don't have their own log driver configured yet
+func getDefaultServiceLogging() map[string]interface{} {
+	driver, options := config.GetDefaultServiceLogging()
+	return manager.NewServiceLogging(driver, options)
+}
+
+// AddMythicService regenerates a Mythic service's docker-compose entry from .env, keeping the keys the CLI doesn't set
 func AddMythicService(service string, removeVolume bool) {
 	pStruct, err := manager.GetManager().GetServiceConfiguration(service)
 	if err != nil {
 		log.Fatalf("[-] Failed to get current configuration information: %v\n", err)
 	}
+	if err = buildMythicServiceConfiguration(service, pStruct, removeVolume); err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+	_ = manager.GetManager().SetServiceConfiguration(service, pStruct)
+}
+
+// InstallMythicService adds a Mythic service to docker-compose for the add and install flows, see setServiceConfiguration
+func InstallMythicService(service string, removeVolume bool) {
+	pStruct := map[string]interface{}{}
+	if err := buildMythicServiceConfiguration(service, pStruct, removeVolume); err != nil {
+		fmt.Printf("%v", err)
+		return
+	}
+	_ = setServiceConfiguration(service, pStruct)
+}
+
+// buildMythicServiceConfiguration updates pStruct with everything a Mythic service needs to run based on .env
+func buildMythicServiceConfiguration(service string, pStruct map[string]interface{}, removeVolume bool) error {
 	if _, ok := pStruct["environment"]; !ok {
 		pStruct["environment"] = []interface{}{}
 	}
@@ -22,12 +62,8 @@ func AddMythicService(service string, removeVolume bool) {
 		"name": service,
 	}
 	pStruct["hostname"] = strings.ToLower(service)
-	pStruct["logging"] = map[string]interface{}{
-		"driver": "json-file",
-		"options": map[string]string{
-			"max-file": "1",
-			"max-size": "10m",
-		},
+	if _, ok := pStruct["logging"]; !ok {
+		pStruct["logging"] = getDefaultServiceLogging()
 	}
 	pStruct["restart"] = config.GetMythicEnv().GetString("global_restart_policy")
 	pStruct["container_name"] = strings.ToLower(service)
@@ -67,10 +103,13 @@ func AddMythicService(service string, removeVolume bool) {
 			"POSTGRES_PORT=${POSTGRES_PORT}",
 		}
 		if _, ok := pStruct["environment"]; ok {
-			pStruct["environment"] = utils.UpdateEnvironmentVariables(pStruct["environment"].([]interface{}), environment)
-		} else {
-			pStruct["environment"] = environment
+			environment = utils.UpdateEnvironmentVariables(pStruct["environment"].([]interface{}), environment)
+		}
+		secretEnvironment, err := applyDockerSecrets(service, pStruct, environment, []string{"postgres_password"})
+		if err != nil {
+			return err
 		}
+		pStruct["environment"] = secretEnvironment
 		if !mythicEnv.GetBool("postgres_use_volume") {
 			pStruct["volumes"] = []string{
 				"./postgres-docker/database:/var/lib/postgresql/data",
@@ -154,10 +193,13 @@ func AddMythicService(service string, removeVolume bool) {
 			"HASURA_GRAPHQL_CONSOLE_ASSETS_DIR=/srv/console-assets",
 		}
 		if _, ok := pStruct["environment"]; ok {
-			pStruct["environment"] = utils.UpdateEnvironmentVariables(pStruct["environment"].([]interface{}), environment)
-		} else {
-			pStruct["environment"] = environment
+			environment = utils.UpdateEnvironmentVariables(pStruct["environment"].([]interface{}), environment)
 		}
+		useSecrets := mythicEnv.GetBool("use_docker_secrets")
+		if err := defineDockerSecrets(useSecrets); err != nil {
+			log.Fatalf("[-] Failed to update docker secrets: %v\n", err)
+		}
+		pStruct["environment"] = applyGraphqlDockerSecrets(pStruct, environment, useSecrets)
 
 		if mythicEnv.GetBool("hasura_bind_localhost_only") {
 			pStruct["ports"] = []string{
@@ -311,7 +353,11 @@ func AddMythicService(service string, removeVolume bool) {
 				finalRabbitEnv = append(finalRabbitEnv, val)
 			}
 		}
-		pStruct["environment"] = finalRabbitEnv
+		secretEnvironment, err := applyDockerSecrets(service, pStruct, finalRabbitEnv, []string{"rabbitmq_password"})
+		if err != nil {
+			return err
+		}
+		pStruct["environment"] = secretEnvironment
 		if !mythicEnv.GetBool("rabbitmq_use_volume") {
 			pStruct["volumes"] = []string{
 				"./rabbitmq-docker/storage:/var/lib/rabbitmq",
@@ -360,10 +406,9 @@ func AddMythicService(service string, removeVolume bool) {
 				}
 			} else {
 				if removeVolume {
-					log.Printf("[*] Removing old volume, %s, if it exists to make room for updated configs", "mythic_react_volume_config")
-					manager.GetManager().RemoveVolume("mythic_react_volume_config")
-					log.Printf("[*] Removing old volume, %s, if it exists to make room for updated UI", "mythic_react_volume_public")
-					manager.GetManager().RemoveVolume("mythic_react_volume_public")
+					utils.LogInfo("[*] Removing old volumes, %s and %s, if they exist to make room for updated configs and UI",
+						"mythic_react_volume_config", "mythic_react_volume_public")
+					manager.GetManager().RemoveVolumes([]string{"mythic_react_volume_config", "mythic_react_volume_public"})
 				}
 				pStruct["volumes"] = []string{
 					"mythic_react_volume_config:/etc/nginx",
@@ -499,10 +544,13 @@ func AddMythicService(service string, removeVolume bool) {
 		}
 		pStruct["ports"] = mythicServerPorts
 		if _, ok := pStruct["environment"]; ok {
-			pStruct["environment"] = utils.UpdateEnvironmentVariables(pStruct["environment"].([]interface{}), environment)
-		} else {
-			pStruct["environment"] = environment
+			environment = utils.UpdateEnvironmentVariables(pStruct["environment"].([]interface{}), environment)
 		}
+		secretEnvironment, err := applyDockerSecrets(service, pStruct, environment, []string{"postgres_password", "rabbitmq_password", "jwt_secret"})
+		if err != nil {
+			return err
+		}
+		pStruct["environment"] = secretEnvironment
 		if !mythicEnv.GetBool("mythic_server_use_volume") {
 			// mount the entire directory in so that you can see changes to code too
 			pStruct["volumes"] = []string{
@@ -521,8 +569,7 @@ func AddMythicService(service string, removeVolume bool) {
 		}
 	case "mythic_sync":
 		if absPath, err := filepath.Abs(filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), service)); err != nil {
-			fmt.Printf("[-] Failed to get abs path for mythic_sync\n")
-			return
+			return errors.New("[-] Failed to get abs path for mythic_sync\n")
 		} else {
 
 			pStruct["build"] = map[string]interface{}{
@@ -560,10 +607,229 @@ func AddMythicService(service string, removeVolume bool) {
 		}
 	}
 	manager.GetManager().SetVolumes(volumes)
-	_ = manager.GetManager().SetServiceConfiguration(service, pStruct)
+	return nil
+}
+
+// applyDockerSecrets swaps the plaintext environment variables for the named secrets with *_FILE variables pointing into
+// /run/secrets when use_docker_secrets is set, and swaps them back when it isn't
+func applyDockerSecrets(service string, pStruct map[string]interface{}, environment []string, secretNames []string) ([]string, error) {
+	mythicEnv := config.GetMythicEnv()
+	useSecrets := mythicEnv.GetBool("use_docker_secrets")
+	if useSecrets {
+		if err := checkDockerSecretsSupport(service, mythicEnv.GetBool); err != nil {
+			return nil, err
+		}
+	}
+	if err := defineDockerSecrets(useSecrets); err != nil {
+		log.Fatalf("[-] Failed to update docker secrets: %v\n", err)
+	}
+	return swapDockerSecretEnv(pStruct, environment, secretNames, useSecrets), nil
+}
+
+// checkDockerSecretsSupport makes sure the code reading a service's credentials understands the *_FILE variables before
+// use_docker_secrets takes the plaintext ones away. mythic_postgres is always fine since the postgres entrypoint reads
+// POSTGRES_PASSWORD_FILE, but the prebuilt mythic_server binary only reads plaintext variables and mythic_rabbitmq's image
+// bakes in a generate_config.sh that does the same (building rabbitmq-docker doesn't replace it, only mounting it does).
+func checkDockerSecretsSupport(service string, getBool func(key string) bool) error {
+	switch service {
+	case "mythic_server":
+		if !getBool("mythic_server_use_build_context") {
+			return errors.New("[-] use_docker_secrets requires mythic_server_use_build_context=true\n" +
+				"\tThe prebuilt ghcr.io/its-a-feature/mythic_server image doesn't read *_FILE secrets and would lose its credentials\n" +
+				"\tEither set mythic_server_use_build_context=true to build it from ./mythic-docker or set use_docker_secrets=false\n")
+		}
+	case "mythic_rabbitmq":
+		if getBool("rabbitmq_use_volume") {
+			return errors.New("[-] use_docker_secrets requires rabbitmq_use_volume=false\n" +
+				"\tWith a volume, mythic_rabbitmq runs the generate_config.sh baked into its image, which doesn't read RABBITMQ_PASSWORD_FILE\n" +
+				"\tEither set rabbitmq_use_volume=false to mount ./rabbitmq-docker/generate_config.sh or set use_docker_secrets=false\n")
+		}
+	}
+	return nil
+}
+
+// swapDockerSecretEnv does the environment and secrets list changes for applyDockerSecrets
+func swapDockerSecretEnv(pStruct map[string]interface{}, environment []string, secretNames []string, useSecrets bool) []string {
+	finalEnvironment := []string{}
+	for _, entry := range environment {
+		key := strings.SplitN(entry, "=", 2)[0]
+		keep := true
+		for _, secretName := range secretNames {
+			envKey := config.DockerSecrets[secretName]
+			if key == envKey+"_FILE" || (useSecrets && key == envKey) {
+				keep = false
+			}
+		}
+		if keep {
+			finalEnvironment = append(finalEnvironment, entry)
+		}
+	}
+	// keep any secrets somebody added by hand, an empty list (instead of no key) makes sure merging removes ours
+	serviceSecrets := []string{}
+	existingSecrets := []string{}
+	switch secrets := pStruct["secrets"].(type) {
+	case []interface{}:
+		for _, existingSecret := range secrets {
+			if secretName, ok := existingSecret.(string); ok {
+				existingSecrets = append(existingSecrets, secretName)
+			}
+		}
+	case []string:
+		existingSecrets = secrets
+	}
+	for _, secretName := range existingSecrets {
+		if !utils.StringInSlice(secretName, secretNames) {
+			serviceSecrets = append(serviceSecrets, secretName)
+		}
+	}
+	if useSecrets {
+		for _, secretName := range secretNames {
+			finalEnvironment = append(finalEnvironment, fmt.Sprintf("%s_FILE=/run/secrets/%s", config.DockerSecrets[secretName], secretName))
+			serviceSecrets = append(serviceSecrets, secretName)
+		}
+	}
+	if _, ok := pStruct["secrets"]; ok || len(serviceSecrets) > 0 {
+		pStruct["secrets"] = serviceSecrets
+	}
+	return finalEnvironment
+}
+
+// graphqlDatabaseURLKeys are mythic_graphql's environment variables that have the postgres password in them
+var graphqlDatabaseURLKeys = []string{"HASURA_GRAPHQL_DATABASE_URL", "HASURA_GRAPHQL_METADATA_DATABASE_URL"}
+
+// graphqlSecretsEntrypoint builds mythic_graphql's database URLs from the postgres_password secret when the container starts,
+// since Hasura only reads them from environment variables. The $$ keeps docker compose from interpolating the command substitution,
+// and the image's own entrypoint and command are run afterwards.
+var graphqlSecretsEntrypoint = []string{
+	"/bin/sh", "-c",
+	`export HASURA_GRAPHQL_DATABASE_URL="postgres://${POSTGRES_USER}:$$(cat /run/secrets/postgres_password)@${POSTGRES_HOST}:${POSTGRES_PORT}/${POSTGRES_DB}" && ` +
+		`export HASURA_GRAPHQL_METADATA_DATABASE_URL="$$HASURA_GRAPHQL_DATABASE_URL" && ` +
+		`exec docker-entrypoint.sh graphql-engine serve`,
+}
+
+// applyGraphqlDockerSecrets keeps the postgres password out of mythic_graphql's environment when use_docker_secrets is set
+// by building the database URLs in graphqlSecretsEntrypoint instead, and puts the URLs back when it isn't
+func applyGraphqlDockerSecrets(pStruct map[string]interface{}, environment []string, useSecrets bool) []string {
+	finalEnvironment := swapDockerSecretEnv(pStruct, environment, []string{"postgres_password"}, useSecrets)
+	// graphql doesn't read POSTGRES_PASSWORD_FILE, it only needs the secret mounted for the entrypoint
+	finalEnvironment = removeEnvironmentKeys(finalEnvironment, []string{config.DockerSecrets["postgres_password"] + "_FILE"})
+	if useSecrets {
+		pStruct["entrypoint"] = graphqlSecretsEntrypoint
+		return removeEnvironmentKeys(finalEnvironment, graphqlDatabaseURLKeys)
+	}
+	if entrypoint, ok := pStruct["entrypoint"]; ok && strings.Contains(fmt.Sprintf("%v", entrypoint), "/run/secrets/postgres_password") {
+		delete(pStruct, "entrypoint")
+	}
+	return finalEnvironment
+}
+
+// removeEnvironmentKeys returns the KEY=VALUE entries whose keys aren't in keys
+func removeEnvironmentKeys(environment []string, keys []string) []string {
+	finalEnvironment := []string{}
+	for _, entry := range environment {
+		if !utils.StringInSlice(strings.SplitN(entry, "=", 2)[0], keys) {
+			finalEnvironment = append(finalEnvironment, entry)
+		}
+	}
+	return finalEnvironment
 }
+
+// defineDockerSecrets adds the file based secrets to docker-compose, or removes the definitions when secrets aren't in use.
+// The files themselves are only written when Mythic starts, see WriteDockerSecretFiles.
+func defineDockerSecrets(useSecrets bool) error {
+	secrets, err := manager.GetManager().GetSecrets()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for secretName := range config.DockerSecrets {
+		_, defined := secrets[secretName]
+		if useSecrets && !defined {
+			secrets[secretName] = map[string]interface{}{
+				"file": "./" + dockerSecretsFolder + "/" + secretName,
+			}
+			changed = true
+		} else if !useSecrets && defined {
+			delete(secrets, secretName)
+			changed = true
+		}
+	}
+	if changed {
+		manager.GetManager().SetSecrets(secrets)
+	}
+	return nil
+}
+
+// dockerSecretsFolder is the folder next to mythic-cli that the file based secrets are written to
+const dockerSecretsFolder = ".secrets"
+
+// WriteDockerSecretFiles writes the current .env values for the secrets to the .secrets folder when use_docker_secrets is set,
+// or removes the folder when it isn't, so the files are only touched when Mythic starts
+func WriteDockerSecretFiles() error {
+	secretsFolder := filepath.Join(utils.GetCwdFromExe(), dockerSecretsFolder)
+	if !config.GetMythicEnv().GetBool("use_docker_secrets") {
+		return os.RemoveAll(secretsFolder)
+	}
+	values := make(map[string]string, len(config.DockerSecrets))
+	for secretName, envKey := range config.DockerSecrets {
+		values[secretName] = config.GetMythicEnv().GetString(envKey)
+	}
+	return writeDockerSecretFiles(secretsFolder, values)
+}
+
+// writeDockerSecretFiles writes each secret to its own file in secretsFolder that only the current user can read
+func writeDockerSecretFiles(secretsFolder string, values map[string]string) error {
+	if err := os.MkdirAll(secretsFolder, 0700); err != nil {
+		return err
+	}
+	// MkdirAll leaves an existing folder's permissions alone
+	if err := os.Chmod(secretsFolder, 0700); err != nil {
+		return err
+	}
+	for secretName, value := range values {
+		secretFile := filepath.Join(secretsFolder, secretName)
+		if err := os.WriteFile(secretFile, []byte(value), 0600); err != nil {
+			return err
+		}
+		// WriteFile only applies the mode to new files
+		if err := os.Chmod(secretFile, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add3rdPartyService regenerates an installed service's docker-compose entry, keeping the keys the CLI doesn't set
 func Add3rdPartyService(service string, additionalConfigs map[string]interface{}, removeVolume bool) error {
 	existingConfig, _ := manager.GetManager().GetServiceConfiguration(service)
+	return manager.GetManager().SetServiceConfiguration(service, build3rdPartyServiceConfiguration(service, existingConfig, additionalConfigs, removeVolume))
+}
+
+// Install3rdPartyService adds an installed service to docker-compose for the add and install flows, see setServiceConfiguration
+func Install3rdPartyService(service string, additionalConfigs map[string]interface{}, removeVolume bool) error {
+	return setServiceConfiguration(service, build3rdPartyServiceConfiguration(service, map[string]interface{}{}, additionalConfigs, removeVolume))
+}
+
+// RegenerateServiceConfig rebuilds an installed service's docker-compose entry from scratch based on its folder on disk.
+// Other services are left alone and so is the service's volume, but any manual changes to the entry are lost.
+func RegenerateServiceConfig(service string) error {
+	if utils.StringInSlice(service, config.MythicPossibleServices) {
+		return errors.New(fmt.Sprintf("[-] %s is a Mythic service, use './mythic-cli add %s --reset' to regenerate it\n", service, service))
+	}
+	servicePath := filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), service)
+	if stat, err := os.Stat(servicePath); err != nil || !stat.IsDir() {
+		return errors.New(fmt.Sprintf("[-] %s isn't installed, failed to find its folder at %s\n", service, servicePath))
+	}
+	pStruct := build3rdPartyServiceConfiguration(service, map[string]interface{}{}, map[string]interface{}{}, false)
+	if err := manager.GetManager().SetServiceConfiguration(service, pStruct); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Regenerated the docker-compose entry for %s\n", service)
+	return nil
+}
+
+// build3rdPartyServiceConfiguration updates existingConfig with everything an installed service needs to run
+func build3rdPartyServiceConfiguration(service string, existingConfig map[string]interface{}, additionalConfigs map[string]interface{}, removeVolume bool) map[string]interface{} {
 	if _, ok := existingConfig["environment"]; !ok {
 		existingConfig["environment"] = []interface{}{}
 	}
@@ -572,12 +838,8 @@ func Add3rdPartyService(service string, additionalConfigs map[string]interface{}
 	}
 	existingConfig["image"] = strings.ToLower(service)
 	existingConfig["hostname"] = strings.ToLower(service)
-	existingConfig["logging"] = map[string]interface{}{
-		"driver": "json-file",
-		"options": map[string]string{
-			"max-file": "1",
-			"max-size": "10m",
-		},
+	if _, ok := existingConfig["logging"]; !ok {
+		existingConfig["logging"] = getDefaultServiceLogging()
 	}
 	existingConfig["restart"] = config.GetMythicEnv().GetString("global_restart_policy")
 	existingConfig["container_name"] = strings.ToLower(service)
@@ -586,6 +848,8 @@ func Add3rdPartyService(service string, additionalConfigs map[string]interface{}
 		"context": filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), service),
 		"args":    config.GetBuildArguments(),
 	}
+	// installed services use host networking, which can't be combined with a networks block
+	delete(existingConfig, "networks")
 	existingConfig["network_mode"] = "host"
 	existingConfig["extra_hosts"] = []string{
 		"mythic_server:127.0.0.1",
@@ -633,8 +897,8 @@ func Add3rdPartyService(service string, additionalConfigs map[string]interface{}
 			}
 			if removeVolume {
 				// blow away the old volume just in case to make sure we don't carry over old data
-				log.Printf("[*] Removing old volume, %s, if it exists", volumeName)
-				manager.GetManager().RemoveVolume(volumeName)
+				utils.LogInfo("[*] Removing old volume, %s, if it exists", volumeName)
+				manager.GetManager().RemoveVolume(volumeName, true)
 			}
 
 			// add our new volume to the list of volumes if needed
@@ -650,9 +914,23 @@ func Add3rdPartyService(service string, additionalConfigs map[string]interface{}
 	if config.GetMythicEnv().GetString("installed_service_mem_limit") != "" {
 		existingConfig["mem_limit"] = config.GetMythicEnv().GetString("installed_service_mem_limit")
 	}
+	if config.GetMythicEnv().GetBool("installed_service_wait_for_healthy") {
+		if dependencies := getInstalledServiceDependencies(); len(dependencies) > 0 {
+			existingConfig["depends_on"] = dependencies
+		} else {
+			delete(existingConfig, "depends_on")
+		}
+	} else {
+		// drop the block from when this was turned on so the service stops waiting on the Mythic services
+		delete(existingConfig, "depends_on")
+	}
 	for key, element := range additionalConfigs {
 		existingConfig[key] = element
 	}
+	// docker compose can't run more than one instance of a service with a fixed container name
+	if scale, ok := existingConfig["scale"].(int); ok && scale > 1 {
+		delete(existingConfig, "container_name")
+	}
 
 	environment := []string{
 		"MYTHIC_ADDRESS=http://${MYTHIC_SERVER_HOST}:${MYTHIC_SERVER_PORT}/agent_message",
@@ -673,24 +951,106 @@ func Add3rdPartyService(service string, additionalConfigs map[string]interface{}
 		"DEBUG_LEVEL=${DEBUG_LEVEL}",
 		"GLOBAL_SERVER_NAME=${GLOBAL_SERVER_NAME}",
 	}
-	existingConfig["environment"] = utils.UpdateEnvironmentVariables(existingConfig["environment"].([]interface{}), environment)
+	environment = utils.UpdateEnvironmentVariables(existingConfig["environment"].([]interface{}), environment)
+	// installed services only get the rabbitmq password as a file if their container library reads RABBITMQ_PASSWORD_FILE
+	useSecrets := config.GetMythicEnv().GetBool("use_docker_secrets") && config.GetMythicEnv().GetBool("installed_service_use_docker_secrets")
+	if useSecrets {
+		if err := defineDockerSecrets(true); err != nil {
+			log.Fatalf("[-] Failed to update docker secrets: %v\n", err)
+		}
+	}
+	existingConfig["environment"] = swapDockerSecretEnv(existingConfig, environment, []string{"rabbitmq_password"}, useSecrets)
 	// only add in volumes if some aren't already listed
 	if _, ok := existingConfig["volumes"]; !ok {
 		existingConfig["volumes"] = []string{
 			filepath.Join(manager.GetManager().GetPathTo3rdPartyServicesOnDisk(), service) + ":/Mythic/",
 		}
 	}
-	return manager.GetManager().SetServiceConfiguration(service, existingConfig)
+	return existingConfig
+}
+
+// installedServiceDependencies are the Mythic services that installed services connect to when they start
+var installedServiceDependencies = []string{"mythic_rabbitmq", "mythic_server"}
+
+// getInstalledServiceDependencies builds a depends_on block that waits for the installed service dependencies in docker-compose to be healthy.
+// The condition form of depends_on is supported by the 2.4 compose file version that mythic-cli writes.
+func getInstalledServiceDependencies() map[string]interface{} {
+	dependencies := map[string]interface{}{}
+	currentServices, err := manager.GetManager().GetCurrentMythicServiceNames()
+	if err != nil {
+		return dependencies
+	}
+	for _, service := range installedServiceDependencies {
+		if utils.StringInSlice(service, currentServices) {
+			dependencies[service] = map[string]interface{}{
+				"condition": "service_healthy",
+			}
+		}
+	}
+	return dependencies
 }
 func RemoveService(service string) error {
 	return manager.GetManager().RemoveServices([]string{service})
 }
 
+// SyncComposeWithDisk reconciles the installed service folders on disk with the entries in docker-compose.
+// If add is true, service folders without a docker-compose entry are added.
+// If remove is true, docker-compose entries for installed services without a folder on disk are removed.
+func SyncComposeWithDisk(add bool, remove bool) (added []string, removed []string, err error) {
+	servicesOnDisk, err := manager.GetManager().GetInstalled3rdPartyServicesOnDisk()
+	if err != nil {
+		return nil, nil, err
+	}
+	servicesInCompose, err := manager.GetManager().GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return nil, nil, err
+	}
+	// docker-compose service names are always lower case
+	var lowerServicesOnDisk []string
+	for _, service := range servicesOnDisk {
+		lowerServicesOnDisk = append(lowerServicesOnDisk, strings.ToLower(service))
+	}
+	if add {
+		for _, service := range servicesOnDisk {
+			if utils.StringInSlice(strings.ToLower(service), servicesInCompose) {
+				continue
+			}
+			if utils.StringInSlice(strings.ToLower(service), config.MythicPossibleServices) {
+				// a folder named after a Mythic service isn't an installed service, './mythic-cli add' handles those
+				continue
+			}
+			if err = Add3rdPartyService(service, make(map[string]interface{}), false); err != nil {
+				return added, removed, err
+			}
+			added = append(added, service)
+		}
+	}
+	if remove {
+		var missingServices []string
+		for _, service := range servicesInCompose {
+			if !utils.StringInSlice(service, lowerServicesOnDisk) {
+				missingServices = append(missingServices, service)
+			}
+		}
+		if len(missingServices) > 0 {
+			if err = manager.GetManager().RemoveServices(missingServices); err != nil {
+				return added, removed, err
+			}
+			removed = missingServices
+		}
+	}
+	return added, removed, nil
+}
+
 func Initialize() {
-	if !manager.GetManager().CheckRequiredManagerVersion() {
-		log.Fatalf("[-] Bad %s version\n", manager.GetManager().GetManagerName())
+	if err := manager.GetManager().CheckRequiredManagerVersion(); err != nil {
+		log.Fatalf("%v", err)
 	}
 	manager.GetManager().GenerateRequiredConfig()
+	// docker-compose files from older versions need to be updated before services get merged into them
+	if _, err := manager.GetManager().MigrateComposeFile(); err != nil {
+		log.Printf("%v", err)
+	}
 	// based on .env, find out which mythic services are supposed to be running and add them to docker compose
 	intendedMythicContainers, _ := config.GetIntendedMythicServiceNames()
 	for _, container := range intendedMythicContainers {
@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSwapDockerSecretEnv(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		pStruct     map[string]interface{}
+		environment []string
+		useSecrets  bool
+		wantEnv     []string
+		wantSecrets interface{}
+	}{
+		{
+			name:        "enable",
+			pStruct:     map[string]interface{}{},
+			environment: []string{"POSTGRES_DB=mythic_db", "POSTGRES_PASSWORD=${POSTGRES_PASSWORD}"},
+			useSecrets:  true,
+			wantEnv:     []string{"POSTGRES_DB=mythic_db", "POSTGRES_PASSWORD_FILE=/run/secrets/postgres_password"},
+			wantSecrets: []string{"postgres_password"},
+		},
+		{
+			name:        "disable",
+			pStruct:     map[string]interface{}{"secrets": []interface{}{"postgres_password"}},
+			environment: []string{"POSTGRES_DB=mythic_db", "POSTGRES_PASSWORD=${POSTGRES_PASSWORD}", "POSTGRES_PASSWORD_FILE=/run/secrets/postgres_password"},
+			useSecrets:  false,
+			wantEnv:     []string{"POSTGRES_DB=mythic_db", "POSTGRES_PASSWORD=${POSTGRES_PASSWORD}"},
+			wantSecrets: []string{},
+		},
+		{
+			name:        "keeps other secrets",
+			pStruct:     map[string]interface{}{"secrets": []interface{}{"my_secret", "postgres_password"}},
+			environment: []string{"POSTGRES_PASSWORD=${POSTGRES_PASSWORD}"},
+			useSecrets:  true,
+			wantEnv:     []string{"POSTGRES_PASSWORD_FILE=/run/secrets/postgres_password"},
+			wantSecrets: []string{"my_secret", "postgres_password"},
+		},
+		{
+			name:        "disabled without secrets",
+			pStruct:     map[string]interface{}{},
+			environment: []string{"POSTGRES_PASSWORD=${POSTGRES_PASSWORD}"},
+			useSecrets:  false,
+			wantEnv:     []string{"POSTGRES_PASSWORD=${POSTGRES_PASSWORD}"},
+			wantSecrets: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := swapDockerSecretEnv(tt.pStruct, tt.environment, []string{"postgres_password"}, tt.useSecrets)
+			if !reflect.DeepEqual(got, tt.wantEnv) {
+				t.Errorf("swapDockerSecretEnv() environment = %v, want %v", got, tt.wantEnv)
+			}
+			if secrets := tt.pStruct["secrets"]; !reflect.DeepEqual(secrets, tt.wantSecrets) {
+				t.Errorf("swapDockerSecretEnv() secrets = %#v, want %#v", secrets, tt.wantSecrets)
+			}
+		})
+	}
+}
+
+func TestCheckDockerSecretsSupport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		service  string
+		settings map[string]bool
+		wantErr  bool
+	}{
+		{name: "postgres prebuilt", service: "mythic_postgres", settings: map[string]bool{}, wantErr: false},
+		{name: "server prebuilt", service: "mythic_server", settings: map[string]bool{}, wantErr: true},
+		{name: "server build context", service: "mythic_server", settings: map[string]bool{"mythic_server_use_build_context": true}, wantErr: false},
+		{name: "rabbitmq mounted script", service: "mythic_rabbitmq", settings: map[string]bool{}, wantErr: false},
+		{name: "rabbitmq volume", service: "mythic_rabbitmq", settings: map[string]bool{"rabbitmq_use_volume": true}, wantErr: true},
+		{name: "rabbitmq volume build context", service: "mythic_rabbitmq", settings: map[string]bool{"rabbitmq_use_volume": true, "rabbitmq_use_build_context": true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkDockerSecretsSupport(tt.service, func(key string) bool { return tt.settings[key] })
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkDockerSecretsSupport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyGraphqlDockerSecrets(t *testing.T) {
+	t.Parallel()
+	environment := []string{
+		"HASURA_GRAPHQL_DATABASE_URL=postgres://${POSTGRES_USER}:${POSTGRES_PASSWORD}@${POSTGRES_HOST}:${POSTGRES_PORT}/${POSTGRES_DB}",
+		"HASURA_GRAPHQL_METADATA_DATABASE_URL=postgres://${POSTGRES_USER}:${POSTGRES_PASSWORD}@${POSTGRES_HOST}:${POSTGRES_PORT}/${POSTGRES_DB}",
+		"HASURA_GRAPHQL_ENABLE_CONSOLE=true",
+	}
+	pStruct := map[string]interface{}{}
+	got := applyGraphqlDockerSecrets(pStruct, environment, true)
+	if want := []string{"HASURA_GRAPHQL_ENABLE_CONSOLE=true"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("applyGraphqlDockerSecrets() with secrets environment = %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(pStruct["entrypoint"], graphqlSecretsEntrypoint) {
+		t.Errorf("applyGraphqlDockerSecrets() with secrets entrypoint = %v, want the secrets entrypoint", pStruct["entrypoint"])
+	}
+	if !reflect.DeepEqual(pStruct["secrets"], []string{"postgres_password"}) {
+		t.Errorf("applyGraphqlDockerSecrets() with secrets secrets = %v, want postgres_password", pStruct["secrets"])
+	}
+
+	got = applyGraphqlDockerSecrets(pStruct, environment, false)
+	if !reflect.DeepEqual(got, environment) {
+		t.Errorf("applyGraphqlDockerSecrets() without secrets environment = %v, want %v", got, environment)
+	}
+	if _, ok := pStruct["entrypoint"]; ok {
+		t.Errorf("applyGraphqlDockerSecrets() without secrets left the entrypoint behind")
+	}
+}
+
+func TestWriteDockerSecretFiles(t *testing.T) {
+	t.Parallel()
+	secretsFolder := filepath.Join(t.TempDir(), ".secrets")
+	if err := os.MkdirAll(secretsFolder, 0755); err != nil {
+		t.Fatalf("failed to make secrets folder: %v", err)
+	}
+	// an existing file with looser permissions gets tightened
+	if err := os.WriteFile(filepath.Join(secretsFolder, "jwt_secret"), []byte("old"), 0644); err != nil {
+		t.Fatalf("failed to write old secret: %v", err)
+	}
+	values := map[string]string{"postgres_password": "pgPassw0rd", "jwt_secret": "jwtSecretValue"}
+	if err := writeDockerSecretFiles(secretsFolder, values); err != nil {
+		t.Fatalf("writeDockerSecretFiles() error = %v", err)
+	}
+	info, err := os.Stat(secretsFolder)
+	if err != nil {
+		t.Fatalf("failed to stat secrets folder: %v", err)
+	}
+	if mode := info.Mode().Perm(); mode != 0700 {
+		t.Errorf("secrets folder mode = %o, want 700", mode)
+	}
+	for secretName, value := range values {
+		secretFile := filepath.Join(secretsFolder, secretName)
+		content, err := os.ReadFile(secretFile)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", secretName, err)
+		}
+		if string(content) != value {
+			t.Errorf("%s = %q, want %q", secretName, content, value)
+		}
+		info, err = os.Stat(secretFile)
+		if err != nil {
+			t.Fatalf("failed to stat %s: %v", secretName, err)
+		}
+		if mode := info.Mode().Perm(); mode != 0600 {
+			t.Errorf("%s mode = %o, want 600", secretName, mode)
+		}
+	}
+}
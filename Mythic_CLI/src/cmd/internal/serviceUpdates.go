@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/config"
+	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
+	"github.com/spf13/viper"
+	"golang.org/x/mod/semver"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServiceUpdate is the result of comparing an installed service's version against the repository it was installed from
+type ServiceUpdate struct {
+	Name             string `json:"name"`
+	Source           string `json:"source"`
+	InstalledVersion string `json:"installed_version"`
+	LatestVersion    string `json:"latest_version"`
+	UpdateAvailable  bool   `json:"update_available"`
+	// Delta is major, minor, or patch when an update is available and both versions are semver
+	Delta string `json:"delta"`
+	Error string `json:"error,omitempty"`
+}
+
+// recordInstallSource saves where each service in a repository was installed from so updates can be checked later.
+// subPath is the folder of the repository that was installed, since that's where its config.json is.
+func recordInstallSource(installPath string, url string, branch string, subPath string) {
+	installConfig := viper.New()
+	installConfig.SetConfigName("config")
+	installConfig.SetConfigType("json")
+	installConfig.AddConfigPath(installPath)
+	if err := installConfig.ReadInConfig(); err != nil {
+		return
+	}
+	for service := range installConfig.GetStringMapString("remote_images") {
+		config.SetNewConfigStrings(fmt.Sprintf("%s_install_url", service), url)
+		config.SetNewConfigStrings(fmt.Sprintf("%s_install_branch", service), branch)
+		config.SetNewConfigStrings(fmt.Sprintf("%s_install_path", service), filepath.ToSlash(subPath))
+	}
+}
+
+// CheckServiceUpdates compares the version of each installed service's remote image against the version in the
+// config.json of the repository it was installed from. Nothing is updated, this only reports what's available.
+func CheckServiceUpdates() ([]ServiceUpdate, error) {
+	installedServices, err := manager.GetManager().GetInstalled3rdPartyServicesOnDisk()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(installedServices)
+	// use a transport with the default TLS verification since the versions reported here decide what users install,
+	// and http.DefaultTransport has verification turned off once Mythic's self-signed endpoints have been tested
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}
+	mythicEnv := config.GetMythicEnv()
+	// services from the same repository share a config.json, so only fetch each one once
+	remoteImagesCache := make(map[string]map[string]string)
+	var updates []ServiceUpdate
+	for _, service := range installedServices {
+		service = strings.ToLower(service)
+		update := ServiceUpdate{
+			Name:             service,
+			Source:           mythicEnv.GetString(service + "_install_url"),
+			InstalledVersion: getImageTag(mythicEnv.GetString(service + "_remote_image")),
+		}
+		if update.Source == "" {
+			update.Source = fmt.Sprintf("https://github.com/MythicAgents/%s", service)
+			if mythicEnv.GetString(service+"_remote_image") != "" &&
+				strings.Contains(strings.ToLower(mythicEnv.GetString(service+"_remote_image")), "mythicc2profiles") {
+				update.Source = fmt.Sprintf("https://github.com/MythicC2Profiles/%s", service)
+			}
+		}
+		if update.InstalledVersion == "" {
+			update.Error = "no installed version recorded"
+			updates = append(updates, update)
+			continue
+		}
+		configURL, err := getRemoteConfigURL(update.Source, mythicEnv.GetString(service+"_install_branch"), mythicEnv.GetString(service+"_install_path"))
+		if err != nil {
+			update.Error = err.Error()
+			updates = append(updates, update)
+			continue
+		}
+		remoteImages, ok := remoteImagesCache[configURL]
+		if !ok {
+			remoteImages, err = fetchRemoteImages(httpClient, configURL)
+			if err != nil {
+				update.Error = err.Error()
+				updates = append(updates, update)
+				continue
+			}
+			remoteImagesCache[configURL] = remoteImages
+		}
+		update.LatestVersion = getImageTag(remoteImages[service])
+		if update.LatestVersion == "" {
+			update.Error = "no remote version found"
+			updates = append(updates, update)
+			continue
+		}
+		update.UpdateAvailable, update.Delta = compareServiceVersions(update.InstalledVersion, update.LatestVersion)
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// getImageTag returns the tag of a docker image reference like ghcr.io/mythicagents/apollo:v0.0.1.3
+func getImageTag(image string) string {
+	lastColon := strings.LastIndex(image, ":")
+	if lastColon < 0 || strings.Contains(image[lastColon:], "/") {
+		return ""
+	}
+	return image[lastColon+1:]
+}
+
+// getRemoteConfigURL converts a GitHub repository URL into the raw URL of its config.json, inside subPath when only that folder was installed
+func getRemoteConfigURL(repoURL string, branch string, subPath string) (string, error) {
+	repoPath := strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	for _, prefix := range []string{"https://github.com/", "http://github.com/", "git@github.com:"} {
+		if strings.HasPrefix(repoPath, prefix) {
+			repoPath = strings.TrimPrefix(repoPath, prefix)
+			if branch == "" {
+				branch = "HEAD"
+			}
+			return fmt.Sprintf("https://raw.githubusercontent.com/%s", path.Join(repoPath, branch, subPath, "config.json")), nil
+		}
+	}
+	return "", errors.New(fmt.Sprintf("can only check GitHub repositories for updates, not %s", repoURL))
+}
+
+// fetchRemoteImages fetches a repository's config.json and returns its remote_images
+func fetchRemoteImages(httpClient *http.Client, configURL string) (map[string]string, error) {
+	resp, err := httpClient.Get(configURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprintf("failed to fetch %s: %s", configURL, resp.Status))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	remoteConfig := struct {
+		RemoteImages map[string]string `json:"remote_images"`
+	}{}
+	if err = json.Unmarshal(body, &remoteConfig); err != nil {
+		return nil, errors.New(fmt.Sprintf("failed to parse remote config.json: %v", err))
+	}
+	remoteImages := make(map[string]string, len(remoteConfig.RemoteImages))
+	for service, image := range remoteConfig.RemoteImages {
+		remoteImages[strings.ToLower(service)] = image
+	}
+	return remoteImages, nil
+}
+
+// compareServiceVersions reports if latest is newer than installed and by how much.
+// Service versions often have four parts (v0.0.1.3), which semver doesn't allow, so those are compared part by part.
+func compareServiceVersions(installed string, latest string) (bool, string) {
+	installedVersion := "v" + strings.TrimPrefix(installed, "v")
+	latestVersion := "v" + strings.TrimPrefix(latest, "v")
+	if semver.IsValid(installedVersion) && semver.IsValid(latestVersion) {
+		if semver.Compare(installedVersion, latestVersion) >= 0 {
+			return false, ""
+		}
+		if semver.Major(installedVersion) != semver.Major(latestVersion) {
+			return true, "major"
+		} else if semver.MajorMinor(installedVersion) != semver.MajorMinor(latestVersion) {
+			return true, "minor"
+		}
+		return true, "patch"
+	}
+	installedParts := strings.Split(strings.TrimPrefix(installedVersion, "v"), ".")
+	latestParts := strings.Split(strings.TrimPrefix(latestVersion, "v"), ".")
+	for i := 0; i < len(installedParts) || i < len(latestParts); i++ {
+		installedPart, latestPart := 0, 0
+		if i < len(installedParts) {
+			installedPart, _ = strconv.Atoi(installedParts[i])
+		}
+		if i < len(latestParts) {
+			latestPart, _ = strconv.Atoi(latestParts[i])
+		}
+		if installedPart != latestPart {
+			return installedPart < latestPart, ""
+		}
+	}
+	return false, ""
+}
+
+func PrintServiceUpdates() {
+	updates, err := CheckServiceUpdates()
+	if err != nil {
+		log.Fatalf("[-] Failed to check for service updates: %v\n", err)
+	}
+	// this is the command's output, so it's printed directly instead of logged to still show up with --quiet
+	if len(updates) == 0 {
+		fmt.Printf("[*] There are no services installed\n")
+		return
+	}
+	for _, update := range updates {
+		if update.Error != "" {
+			fmt.Printf("[-] %s: couldn't check for updates: %s\n", update.Name, update.Error)
+		} else if update.UpdateAvailable && update.Delta != "" {
+			fmt.Printf("[+] %s: %s update available, %s -> %s\n", update.Name, update.Delta, update.InstalledVersion, update.LatestVersion)
+		} else if update.UpdateAvailable {
+			fmt.Printf("[+] %s: update available, %s -> %s\n", update.Name, update.InstalledVersion, update.LatestVersion)
+		} else {
+			fmt.Printf("[*] %s: up to date (%s)\n", update.Name, update.InstalledVersion)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package internal
+
+import "testing"
+
+func TestGetRemoteConfigURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		repoURL string
+		branch  string
+		subPath string
+		want    string
+		wantErr bool
+	}{
+		{name: "default branch", repoURL: "https://github.com/MythicAgents/apollo", want: "https://raw.githubusercontent.com/MythicAgents/apollo/HEAD/config.json"},
+		{name: "branch", repoURL: "https://github.com/MythicAgents/apollo.git", branch: "dev", want: "https://raw.githubusercontent.com/MythicAgents/apollo/dev/config.json"},
+		{name: "ssh", repoURL: "git@github.com:MythicAgents/apollo.git", want: "https://raw.githubusercontent.com/MythicAgents/apollo/HEAD/config.json"},
+		{name: "sub path", repoURL: "https://github.com/MythicMeta/services/", branch: "main", subPath: "agents/apollo",
+			want: "https://raw.githubusercontent.com/MythicMeta/services/main/agents/apollo/config.json"},
+		{name: "not github", repoURL: "https://gitlab.com/MythicAgents/apollo", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := getRemoteConfigURL(tt.repoURL, tt.branch, tt.subPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getRemoteConfigURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getRemoteConfigURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
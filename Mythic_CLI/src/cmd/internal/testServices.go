@@ -2,14 +2,20 @@ package internal
 
 import (
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
 	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
+	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
 	"github.com/streadway/amqp"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"regexp"
 	"strconv"
+	"text/template"
 	"time"
 )
 
@@ -33,9 +39,9 @@ func TestMythicConnection() {
 	sleepTime := int64(10)
 	count := make([]int, maxCount)
 	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	log.Printf("[*] Waiting for Mythic Server and Nginx to come online (Retry Count = %d)\n", maxCount)
+	utils.LogInfo("[*] Waiting for Mythic Server and Nginx to come online (Retry Count = %d)\n", maxCount)
 	for i := range count {
-		log.Printf("[*] Attempting to connect to Mythic UI at %s:%d, attempt %d/%d\n", webAddress, mythicEnv.GetInt("NGINX_PORT"), i+1, maxCount)
+		utils.LogVerbose("[*] Attempting to connect to Mythic UI at %s:%d, attempt %d/%d\n", webAddress, mythicEnv.GetInt("NGINX_PORT"), i+1, maxCount)
 		resp, err := http.Get(webAddress + ":" + strconv.Itoa(mythicEnv.GetInt("NGINX_PORT")))
 		if err != nil {
 			log.Printf("[-] Failed to make connection to host, retrying in %ds\n", sleepTime)
@@ -43,7 +49,7 @@ func TestMythicConnection() {
 		} else {
 			resp.Body.Close()
 			if resp.StatusCode == 200 || resp.StatusCode == 404 {
-				log.Printf("[+] Successfully connected to Mythic at " + webAddress + ":" + strconv.Itoa(mythicEnv.GetInt("NGINX_PORT")) + "\n\n")
+				utils.LogInfo("[+] Successfully connected to Mythic at %s:%d\n\n", webAddress, mythicEnv.GetInt("NGINX_PORT"))
 				return
 			} else if resp.StatusCode == 502 || resp.StatusCode == 504 {
 				log.Printf("[-] Nginx is up, but waiting for Mythic Server, retrying connection in %ds\n", sleepTime)
@@ -56,9 +62,9 @@ func TestMythicConnection() {
 	log.Printf("[-] Failed to make connection to Mythic Server\n")
 	log.Printf("    This could be due to limited resources on the host (recommended at least 2CPU and 4GB RAM)\n")
 	log.Printf("    If there is an issue with Mythic server, use 'mythic-cli logs mythic_server' to view potential errors\n")
-	Status(false)
-	log.Printf("[*] Fetching logs from mythic_server now:\n")
-	GetLogs("mythic_server", "500", false)
+	Status(false, "", "")
+	utils.LogInfo("[*] Fetching logs from mythic_server now:\n")
+	GetLogs(os.Stdout, "mythic_server", "500", false, nil)
 	os.Exit(1)
 }
 func TestMythicRabbitmqConnection() {
@@ -76,16 +82,16 @@ func TestMythicRabbitmqConnection() {
 	var err error
 	count := make([]int, maxCount)
 	sleepTime := int64(10)
-	log.Printf("[*] Waiting for RabbitMQ to come online (Retry Count = %d)\n", maxCount)
+	utils.LogInfo("[*] Waiting for RabbitMQ to come online (Retry Count = %d)\n", maxCount)
 	for i := range count {
-		log.Printf("[*] Attempting to connect to RabbitMQ at %s:%s, attempt %d/%d\n", rabbitmqAddress, rabbitmqPort, i+1, maxCount)
+		utils.LogVerbose("[*] Attempting to connect to RabbitMQ at %s:%s, attempt %d/%d\n", rabbitmqAddress, rabbitmqPort, i+1, maxCount)
 		conn, err := amqp.Dial(fmt.Sprintf("amqp://%s:%s@%s:%s/mythic_vhost", mythicEnv.GetString("RABBITMQ_USER"), mythicEnv.GetString("RABBITMQ_PASSWORD"), rabbitmqAddress, rabbitmqPort))
 		if err != nil {
 			log.Printf("[-] Failed to connect to RabbitMQ, retrying in %ds\n", sleepTime)
 			time.Sleep(10 * time.Second)
 		} else {
 			conn.Close()
-			log.Printf("[+] Successfully connected to RabbitMQ at amqp://%s:***@%s:%s/mythic_vhost\n\n", mythicEnv.GetString("RABBITMQ_USER"), rabbitmqAddress, rabbitmqPort)
+			utils.LogInfo("[+] Successfully connected to RabbitMQ at amqp://%s:***@%s:%s/mythic_vhost\n\n", mythicEnv.GetString("RABBITMQ_USER"), rabbitmqAddress, rabbitmqPort)
 			return
 		}
 	}
@@ -107,19 +113,37 @@ func TestPorts() error {
 	return nil
 }
 
-func Status(verbose bool) {
-	manager.GetManager().PrintConnectionInfo()
-	manager.GetManager().Status(verbose)
+func ConnectionInfo(jsonOutput bool, showSecrets bool) {
+	if !jsonOutput {
+		manager.GetManager().PrintConnectionInfo(showSecrets)
+		return
+	}
+	if err := manager.GetManager().ConnectionInfoJSON(os.Stdout, showSecrets); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+// Status prints the connection info and the state of the services, only showing services that match filter if one is given.
+// When format is set, it's used as a text/template for each service's manager.ServiceInfo instead, like docker ps --format.
+func Status(verbose bool, filter string, format string) {
+	if format != "" {
+		if err := printServiceStatusFormat(filter, format); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+	manager.GetManager().PrintConnectionInfo(false)
+	manager.GetManager().Status(verbose, filter)
 	installedServices, err := manager.GetManager().GetInstalled3rdPartyServicesOnDisk()
 	if err != nil {
 		log.Fatalf("[-] failed to get installed services: %v\n", err)
 	}
 	mythicEnv := config.GetMythicEnv()
 	if len(installedServices) == 0 {
-		log.Printf("[*] There are no services installed\n")
-		log.Printf("    To install one, use \"sudo ./mythic-cli install github <url>\"\n")
-		log.Printf("    Agents can be found at: https://github.com/MythicAgents\n")
-		log.Printf("    C2 Profiles can be found at: https://github.com/MythicC2Profiles\n")
+		utils.LogInfo("[*] There are no services installed\n")
+		utils.LogInfo("    To install one, use \"sudo ./mythic-cli install github <url>\"\n")
+		utils.LogInfo("    Agents can be found at: https://github.com/MythicAgents\n")
+		utils.LogInfo("    C2 Profiles can be found at: https://github.com/MythicC2Profiles\n")
 	}
 	if mythicEnv.GetString("RABBITMQ_HOST") == "mythic_rabbitmq" && mythicEnv.GetBool("rabbitmq_bind_localhost_only") {
 		log.Printf("\n[*] RabbitMQ is currently listening on localhost. If you have a remote Service, they will be unable to connect (i.e. one running on another server)")
@@ -129,15 +153,64 @@ func Status(verbose bool) {
 		log.Printf("\n[*] MythicServer is currently listening on localhost. If you have a remote Service, they will be unable to connect (i.e. one running on another server)")
 		log.Printf("\n    Use 'sudo ./mythic-cli config set mythic_server_bind_localhost_only false' and restart mythic ('sudo ./mythic-cli restart') to change this\n")
 	}
-	log.Printf("[*] If you are using a remote PayloadType or C2Profile, they will need certain environment variables to properly connect to Mythic.\n")
-	log.Printf("    Use 'sudo ./mythic-cli config service' for configs for these services.\n")
+	utils.LogInfo("[*] If you are using a remote PayloadType or C2Profile, they will need certain environment variables to properly connect to Mythic.\n")
+	utils.LogInfo("    Use 'sudo ./mythic-cli config service' for configs for these services.\n")
+}
+
+// printServiceStatusFormat executes format for every service that matches filter, with one line per service
+func printServiceStatusFormat(filter string, format string) error {
+	statusTemplate, err := template.New("status").Funcs(template.FuncMap{
+		"json": func(value interface{}) (string, error) {
+			output, err := json.Marshal(value)
+			return string(output), err
+		},
+	}).Parse(format)
+	if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to parse format: %v\n", err))
+	}
+	services, err := manager.GetManager().GetServiceStatuses(filter)
+	if err != nil {
+		return err
+	}
+	for _, service := range services {
+		if err = statusTemplate.Execute(os.Stdout, service); err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to format %s: %v\n", service.Name, err))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+func GetLogs(w io.Writer, containerName string, numLogs string, follow bool, options *manager.LogOptions) {
+	logCount, err := strconv.Atoi(numLogs)
+	if err != nil {
+		log.Fatalf("[-] Bad log count: %v\n", err)
+	}
+	manager.GetManager().GetLogs(w, containerName, logCount, follow, options)
 }
-func GetLogs(containerName string, numLogs string, follow bool) {
+func GetLogsMulti(w io.Writer, containerNames []string, numLogs string, follow bool, options *manager.LogOptions) {
 	logCount, err := strconv.Atoi(numLogs)
 	if err != nil {
 		log.Fatalf("[-] Bad log count: %v\n", err)
 	}
-	manager.GetManager().GetLogs(containerName, logCount, follow)
+	manager.GetManager().GetLogsMulti(w, containerNames, logCount, follow, options)
+}
+func GetLogOptions(filterPattern string, invertFilter bool, timestamps bool, since string, until string) *manager.LogOptions {
+	return &manager.LogOptions{
+		Filter:     getLogFilter(filterPattern, invertFilter),
+		Timestamps: timestamps,
+		Since:      since,
+		Until:      until,
+	}
+}
+func getLogFilter(filterPattern string, invertFilter bool) *manager.LogFilter {
+	if filterPattern == "" {
+		return nil
+	}
+	pattern, err := regexp.Compile(filterPattern)
+	if err != nil {
+		log.Fatalf("[-] Bad filter pattern: %v\n", err)
+	}
+	return &manager.LogFilter{Pattern: pattern, Invert: invertFilter}
 }
 func ListServices() {
 	manager.GetManager().PrintAllServices()
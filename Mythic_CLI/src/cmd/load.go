@@ -9,8 +9,8 @@ import (
 // configCmd represents the config command
 var loadCmd = &cobra.Command{
 	Use:   "load",
-	Short: "Load tar versions of Mythic images from ./saved_images/mythic_save.tar",
-	Long:  `Run this command to load TAR files for exported images generated via the 'save' command.`,
+	Short: "Load tar versions of Mythic images from ./saved_images",
+	Long:  `Run this command to load every TAR file in ./saved_images for exported images generated via the 'save' command.`,
 	Run:   load,
 }
 
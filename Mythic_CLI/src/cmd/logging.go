@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+// loggingCmd represents the logging command
+var loggingCmd = &cobra.Command{
+	Use:   "logging [service name] [driver] [option=value] ...",
+	Short: "Set the Docker log driver for a service",
+	Long: `Run this command to change where a service's logs go, like 'logging mythic_server syslog syslog-address=udp://1.2.3.4:514'.
+Valid drivers are json-file, local, syslog, journald, fluentd, gelf, awslogs, splunk, and none.
+New services use the global_log_driver and global_log_options settings in the .env instead.`,
+	Run:  logging,
+	Args: cobra.MinimumNArgs(2),
+}
+
+func init() {
+	rootCmd.AddCommand(loggingCmd)
+}
+
+func logging(cmd *cobra.Command, args []string) {
+	options := make(map[string]string)
+	for _, option := range args[2:] {
+		key, value, found := strings.Cut(option, "=")
+		if !found || key == "" {
+			fmt.Printf("[-] options must be in the form key=value: %s\n", option)
+			os.Exit(1)
+		}
+		options[key] = value
+	}
+	if err := internal.ServiceLogging(args[0], args[1], options); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
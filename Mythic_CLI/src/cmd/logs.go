@@ -3,17 +3,25 @@ package cmd
 import (
 	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
 	"github.com/spf13/cobra"
+	"os"
 )
 
 // configCmd represents the config command
 var logsCmd = &cobra.Command{
-	Use:   "logs [container name]",
-	Short: "Get docker logs from a running service",
-	Long:  `Run this command to get Docker logs from a running service.`,
-	Run:   getLogs,
-	Args:  cobra.ExactArgs(1),
+	Use:   "logs [container names]",
+	Short: "Get docker logs from running services",
+	Long: `Run this command to get Docker logs from a running service.
+Specify multiple services to interleave their logs with each line prefixed by the service name.`,
+	Run:  getLogs,
+	Args: cobra.MinimumNArgs(1),
 }
 
+var logsGrep string
+var logsInvertMatch bool
+var logsTimestamps bool
+var logsSince string
+var logsUntil string
+
 func init() {
 	rootCmd.AddCommand(logsCmd)
 	logsCmd.Flags().StringP("lines", "l", "200", "Number of lines to display")
@@ -23,13 +31,51 @@ func init() {
 		false,
 		`Follow a constant stream of logs from the specified container.`,
 	)
+	logsCmd.Flags().StringVarP(
+		&logsGrep,
+		"grep",
+		"g",
+		"",
+		`Only display log lines matching this regular expression`,
+	)
+	logsCmd.Flags().BoolVarP(
+		&logsInvertMatch,
+		"invert-match",
+		"v",
+		false,
+		`Only display log lines that don't match the --grep regular expression`,
+	)
+	logsCmd.Flags().BoolVarP(
+		&logsTimestamps,
+		"timestamps",
+		"t",
+		false,
+		`Prefix each log line with the time it was logged`,
+	)
+	logsCmd.Flags().StringVar(
+		&logsSince,
+		"since",
+		"",
+		`Only show logs since a timestamp (ex: 2024-01-02T15:04:05Z) or relative time (ex: 42m). Use --lines 0 to show the whole window`,
+	)
+	logsCmd.Flags().StringVar(
+		&logsUntil,
+		"until",
+		"",
+		`Only show logs before a timestamp (ex: 2024-01-02T15:04:05Z) or relative time (ex: 42m)`,
+	)
 }
 
 func getLogs(cmd *cobra.Command, args []string) {
+	logOptions := internal.GetLogOptions(logsGrep, logsInvertMatch, logsTimestamps, logsSince, logsUntil)
+	if len(args) > 1 {
+		internal.GetLogsMulti(os.Stdout, args, cmd.Flag("lines").Value.String(), cmd.Flag("follow").Value.String() == "true", logOptions)
+		return
+	}
 	if cmd.Flag("follow").Value.String() == "true" {
-		internal.GetLogs(args[0], cmd.Flag("lines").Value.String(), true)
+		internal.GetLogs(os.Stdout, args[0], cmd.Flag("lines").Value.String(), true, logOptions)
 	} else {
-		internal.GetLogs(args[0], cmd.Flag("lines").Value.String(), false)
+		internal.GetLogs(os.Stdout, args[0], cmd.Flag("lines").Value.String(), false, logOptions)
 	}
 
 }
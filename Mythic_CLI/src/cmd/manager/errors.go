@@ -0,0 +1,43 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Failure modes that callers can check for with errors.Is instead of matching on error messages
+var (
+	ErrDockerUnavailable   = errors.New("docker is unavailable")
+	ErrImageNotFound       = errors.New("image not found")
+	ErrVolumeNotFound      = errors.New("volume not found")
+	ErrContainerNotRunning = errors.New("container isn't running")
+)
+
+// ManagerError is an error with a user facing message that's one of the failure modes above (Kind), optionally wrapping the error that caused it
+type ManagerError struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+func (e *ManagerError) Error() string {
+	return e.Message
+}
+
+// Is lets errors.Is match on the kind of failure
+func (e *ManagerError) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *ManagerError) Unwrap() error {
+	return e.Err
+}
+
+// newManagerError builds a ManagerError of the specified kind with a formatted message
+func newManagerError(kind error, err error, format string, args ...interface{}) error {
+	return &ManagerError{
+		Kind:    kind,
+		Message: fmt.Sprintf(format, args...),
+		Err:     err,
+	}
+}
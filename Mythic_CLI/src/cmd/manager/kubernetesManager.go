@@ -0,0 +1,2062 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/config"
+	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-units"
+	"github.com/moby/term"
+	"github.com/spf13/viper"
+	"io"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+	"k8s.io/client-go/util/retry"
+	"log"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+// KubernetesManager runs Mythic services in a Kubernetes cluster with client-go.
+//
+//	The cluster is found with the same kubeconfig loading rules kubectl uses (KUBECONFIG, then ~/.kube/config, and its current context),
+//	unless kubernetes_kubeconfig or kubernetes_context pick a different cluster.
+//	Service definitions are still tracked in docker-compose.yml (merged with docker-compose.override.yml) and translated
+//	into Deployments, Services, and PersistentVolumeClaims when services are started. Kubernetes names can't contain underscores, so a service
+//	like mythic_server is reachable in the cluster as mythic-server, and references to mythic_server in environment variables and commands
+//	are rewritten to match. Images that docker compose builds locally have to be pushed to kubernetes_image_registry for the cluster to pull them.
+type KubernetesManager struct {
+	Namespace string
+	// compose stores and reads the service definitions that get translated into Kubernetes resources
+	compose *DockerComposeManager
+	// clientset is created from the kubeconfig the first time it's needed and restConfig is what it was created from,
+	// exec sessions (shells and copies) open their own connections with it
+	clientset   kubernetes.Interface
+	restConfig  *rest.Config
+	clientsetMu sync.Mutex
+}
+
+// kubernetesPartOfSelector selects every resource the Kubernetes manager creates for Mythic
+const kubernetesPartOfSelector = "app.kubernetes.io/part-of=mythic"
+
+// kubernetesRestartedAtAnnotation is the pod template annotation kubectl rollout restart sets, changing it rolls a Deployment's pods
+const kubernetesRestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// Interface Necessary commands
+
+func (k *KubernetesManager) GetManagerName() string {
+	return "kubernetes"
+}
+
+// IsServiceRunning checks if the service's deployment has at least one ready replica
+func (k *KubernetesManager) IsServiceRunning(service string) bool {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return false
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	deployment, err := clientset.AppsV1().Deployments(k.Namespace).Get(ctx, getKubernetesName(service), metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	return deployment.Status.ReadyReplicas > 0
+}
+
+// GetManagerVersion returns the Kubernetes version of the cluster
+func (k *KubernetesManager) GetManagerVersion() (string, error) {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return "", err
+	}
+	serverVersion, err := clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("[-] Failed to get the Kubernetes version: %v\n", err))
+	}
+	return serverVersion.GitVersion, nil
+}
+
+// CheckRequiredManagerVersion makes sure the kubeconfig loads and the cluster it points to responds
+func (k *KubernetesManager) CheckRequiredManagerVersion() error {
+	if _, err := k.GetManagerVersion(); err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to connect to the Kubernetes cluster, check your kubeconfig context: %v\n", err))
+	}
+	return nil
+}
+
+// GenerateRequiredConfig ensures the docker-compose.yml file for service definitions and the namespace exist
+func (k *KubernetesManager) GenerateRequiredConfig() {
+	k.compose.GenerateRequiredConfig()
+	clientset, err := k.getClientset()
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	_, err = clientset.CoreV1().Namespaces().Get(ctx, k.Namespace, metav1.GetOptions{})
+	if err == nil {
+		return
+	} else if !apierrors.IsNotFound(err) {
+		log.Fatalf("[-] Failed to get namespace %s: %v\n", k.Namespace, err)
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: k.Namespace}}
+	if _, err = clientset.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{}); err != nil {
+		log.Fatalf("[-] Failed to create namespace %s: %v\n", k.Namespace, err)
+	}
+	utils.LogInfo("[+] Successfully created namespace %s\n", k.Namespace)
+}
+
+// DoesImageExist checks if the image the cluster pulls for the service is in its registry.
+// The registry is checked through the local Docker daemon that builds and pushes the images, with the same credentials as './mythic-cli push'.
+func (k *KubernetesManager) DoesImageExist(service string) bool {
+	image, err := getKubernetesImage(k.compose.readInDockerComposeWithOverride(), service)
+	if err != nil || image == "" {
+		return false
+	}
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		log.Printf("[-] Failed to connect to docker api: %v\n", err)
+		return false
+	}
+	defer cli.Close()
+	registryAuth, err := k.compose.getRegistryAuth(cli, image)
+	if err != nil {
+		log.Printf("%v", err)
+		return false
+	}
+	err = k.compose.withDockerContext(func(ctx context.Context) error {
+		_, err := cli.DistributionInspect(ctx, image, registryAuth)
+		return err
+	})
+	return err == nil
+}
+
+// RemoveImages removes the unused local images left over from building images for the cluster, the cluster's nodes manage their own images
+func (k *KubernetesManager) RemoveImages(dryRun bool) ([]string, error) {
+	return k.compose.RemoveImages(dryRun)
+}
+
+// RemoveImagesOlderThan removes old local images left over from building images for the cluster
+func (k *KubernetesManager) RemoveImagesOlderThan(olderThan time.Duration, keepRunning bool) error {
+	return k.compose.RemoveImagesOlderThan(olderThan, keepRunning)
+}
+
+// Prune removes the dangling local images and build cache from building images for the cluster,
+// along with the PersistentVolumeClaims that don't belong to a service in docker-compose anymore if includeVolumes is true
+func (k *KubernetesManager) Prune(includeVolumes bool, includeBuildCache bool) error {
+	if err := k.compose.Prune(false, includeBuildCache); err != nil {
+		return err
+	}
+	if !includeVolumes {
+		return nil
+	}
+	orphanedClaims, _, _, err := k.FindOrphans()
+	if err != nil {
+		return err
+	}
+	return k.RemoveVolumes(orphanedClaims)
+}
+
+// SaveImages saves the locally built images, ex: to push them to a registry the cluster can reach from another host
+func (k *KubernetesManager) SaveImages(services []string, outputPath string) error {
+	return k.compose.SaveImages(services, outputPath)
+}
+
+func (k *KubernetesManager) SaveImagesSeparately(services []string, outputDir string) error {
+	return k.compose.SaveImagesSeparately(services, outputDir)
+}
+
+func (k *KubernetesManager) LoadImages(outputPath string) error {
+	return k.compose.LoadImages(outputPath)
+}
+
+// PushImages pushes locally built images with docker so the cluster can pull them from the registry
+func (k *KubernetesManager) PushImages(services []string, registry string) error {
+	return k.compose.PushImages(services, registry)
+}
+
+// PullImages pulls images into the local docker, the cluster pulls its own images when pods start
+func (k *KubernetesManager) PullImages(services []string, registry string) error {
+	return k.compose.PullImages(services, registry)
+}
+
+// RemoveContainers deletes the deployments for the specified services
+func (k *KubernetesManager) RemoveContainers(services []string) error {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	for _, service := range services {
+		err = clientset.AppsV1().Deployments(k.Namespace).Delete(ctx, getKubernetesName(service), metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.New(fmt.Sprintf("[-] Failed to remove %s: %v\n", service, err))
+		}
+	}
+	return nil
+}
+
+func (k *KubernetesManager) GetVolumes() (map[string]interface{}, error) {
+	return k.compose.GetVolumes()
+}
+
+func (k *KubernetesManager) SetVolumes(volumes map[string]interface{}) {
+	k.compose.SetVolumes(volumes)
+}
+
+func (k *KubernetesManager) GetNetworks() (map[string]interface{}, error) {
+	return k.compose.GetNetworks()
+}
+
+func (k *KubernetesManager) SetNetworks(networks map[string]interface{}) {
+	k.compose.SetNetworks(networks)
+}
+
+func (k *KubernetesManager) GetSecrets() (map[string]interface{}, error) {
+	return k.compose.GetSecrets()
+}
+
+func (k *KubernetesManager) SetSecrets(secrets map[string]interface{}) {
+	k.compose.SetSecrets(secrets)
+}
+
+// AttachServiceToNetwork isn't supported since every pod in the cluster already shares the cluster's network
+func (k *KubernetesManager) AttachServiceToNetwork(service string, network string) error {
+	return errKubernetesNotSupported("attaching services to networks")
+}
+
+func (k *KubernetesManager) GetServiceConfiguration(service string) (map[string]interface{}, error) {
+	return k.compose.GetServiceConfiguration(service)
+}
+
+func (k *KubernetesManager) GetRawServiceConfiguration(service string) (map[string]interface{}, error) {
+	return k.compose.GetRawServiceConfiguration(service)
+}
+
+func (k *KubernetesManager) SetServiceConfiguration(service string, pStruct map[string]interface{}) error {
+	return k.compose.SetServiceConfiguration(service, pStruct)
+}
+
+func (k *KubernetesManager) MergeServiceConfiguration(service string, pStruct map[string]interface{}) error {
+	return k.compose.MergeServiceConfiguration(service, pStruct)
+}
+
+func (k *KubernetesManager) SetServiceResourceLimits(service string, cpus float64, memoryMB int) error {
+	return k.compose.SetServiceResourceLimits(service, cpus, memoryMB)
+}
+
+// SetServiceLogging isn't supported since pod logs are collected by the cluster's own logging
+func (k *KubernetesManager) SetServiceLogging(service string, driver string, options map[string]string) error {
+	return errKubernetesNotSupported("configuring log drivers")
+}
+
+// SetServiceHealthcheck saves the healthcheck in docker-compose, it becomes the readiness probe for the service's pods the next time it's started
+func (k *KubernetesManager) SetServiceHealthcheck(service string, hc Healthcheck) error {
+	return k.compose.SetServiceHealthcheck(service, hc)
+}
+
+func (k *KubernetesManager) SetServicePlatform(service string, platform string) error {
+	return k.compose.SetServicePlatform(service, platform)
+}
+
+func (k *KubernetesManager) GetServiceResourceLimits(service string) (float64, int, error) {
+	return k.compose.GetServiceResourceLimits(service)
+}
+
+// PrintComposeConfig writes the Kubernetes manifests generated for every service in docker-compose to w
+func (k *KubernetesManager) PrintComposeConfig(w io.Writer) error {
+	services, err := k.GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return err
+	}
+	mythicServices, err := k.GetCurrentMythicServiceNames()
+	if err != nil {
+		return err
+	}
+	services = append(mythicServices, services...)
+	for _, service := range services {
+		manifests, err := k.getServiceManifests(service)
+		if err != nil {
+			return err
+		}
+		for _, manifest := range manifests.objects() {
+			content, err := yaml.Marshal(manifest)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "---\n%s", content)
+		}
+	}
+	return nil
+}
+
+func (k *KubernetesManager) RestoreComposeBackup() error {
+	return k.compose.RestoreComposeBackup()
+}
+
+func (k *KubernetesManager) MigrateComposeFile() (bool, error) {
+	return k.compose.MigrateComposeFile()
+}
+
+func (k *KubernetesManager) DiffComposeFiles(pathA string, pathB string) (string, error) {
+	return k.compose.DiffComposeFiles(pathA, pathB)
+}
+
+// StopServices scales the deployments for the services down to zero, or deletes them if deleteImages is true
+func (k *KubernetesManager) StopServices(services []string, deleteImages bool) error {
+	if len(services) == 0 {
+		installedServices, err := k.GetAllInstalled3rdPartyServiceNames()
+		if err != nil {
+			return err
+		}
+		mythicServices, err := k.GetCurrentMythicServiceNames()
+		if err != nil {
+			return err
+		}
+		services = append(installedServices, mythicServices...)
+	}
+	if deleteImages {
+		return k.RemoveContainers(services)
+	}
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	for _, service := range services {
+		if err = k.scaleDeployment(ctx, clientset, service, 0); err != nil && !apierrors.IsNotFound(err) {
+			log.Printf("[-] Failed to stop %s: %v\n", service, err)
+		}
+	}
+	return nil
+}
+
+// RemoveServices deletes the Kubernetes resources for the services and removes them from docker-compose
+func (k *KubernetesManager) RemoveServices(services []string) error {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	for _, service := range services {
+		name := getKubernetesName(service)
+		err = clientset.AppsV1().Deployments(k.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err == nil || apierrors.IsNotFound(err) {
+			err = clientset.CoreV1().Services(k.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Printf("[-] Failed to remove %s from the cluster: %v\n", service, err)
+		}
+	}
+	curConfig := k.compose.readInDockerCompose()
+	allConfigValues := curConfig.AllSettings()
+	if allServices, ok := allConfigValues["services"].(map[string]interface{}); ok {
+		for _, service := range services {
+			delete(allServices, strings.ToLower(service))
+			utils.LogInfo("[+] Removed %s from docker-compose\n", strings.ToLower(service))
+		}
+	}
+	return k.compose.setDockerComposeDefaultsAndWrite(allConfigValues)
+}
+
+// StartServices translates the services into Kubernetes resources and creates or updates them in the cluster.
+// The Deployments are scaled back up to the scale in docker-compose, and rebuildOnStart rolls their pods so updated images are pulled.
+func (k *KubernetesManager) StartServices(services []string, rebuildOnStart bool) error {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	for _, service := range services {
+		manifests, err := k.getServiceManifests(service)
+		if err != nil {
+			return err
+		}
+		if err = k.applyServiceManifests(ctx, clientset, manifests, rebuildOnStart); err != nil {
+			return errors.New(fmt.Sprintf("[-] Failed to apply %s: %v\n", service, err))
+		}
+		utils.LogInfo("[+] Successfully applied %s\n", service)
+	}
+	return nil
+}
+
+// BuildServices builds the images with docker, pushes them to kubernetes_image_registry, and rolls the services' pods so the cluster pulls the new images.
+// docker compose builds the images one at a time, so maxParallel isn't used.
+func (k *KubernetesManager) BuildServices(services []string, maxParallel int, noCache bool) error {
+	registry := config.GetMythicEnv().GetString("kubernetes_image_registry")
+	if registry == "" {
+		return errors.New("[-] Set kubernetes_image_registry to the registry the cluster pulls built images from before building\n")
+	}
+	if err := k.compose.BuildOnly(services, noCache); err != nil {
+		return err
+	}
+	if err := k.compose.PushImages(services, registry); err != nil {
+		return err
+	}
+	return k.StartServices(services, true)
+}
+
+// BuildOnly builds images locally with docker, they still need to be pushed to kubernetes_image_registry before starting
+func (k *KubernetesManager) BuildOnly(services []string, noCache bool) error {
+	return k.compose.BuildOnly(services, noCache)
+}
+
+func (k *KubernetesManager) GetInstalled3rdPartyServicesOnDisk() ([]string, error) {
+	return k.compose.GetInstalled3rdPartyServicesOnDisk()
+}
+
+func (k *KubernetesManager) GetAllInstalled3rdPartyServiceNames() ([]string, error) {
+	return k.compose.GetAllInstalled3rdPartyServiceNames()
+}
+
+func (k *KubernetesManager) GetCurrentMythicServiceNames() ([]string, error) {
+	return k.compose.GetCurrentMythicServiceNames()
+}
+
+func (k *KubernetesManager) GetPathTo3rdPartyServicesOnDisk() string {
+	return k.compose.GetPathTo3rdPartyServicesOnDisk()
+}
+
+// GetHealthCheck prints the readiness of the pods backing each service
+func (k *KubernetesManager) GetHealthCheck(services []string) {
+	clientset, err := k.getClientset()
+	if err != nil {
+		log.Printf("failed to check status: %s", err.Error())
+		return
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	for _, service := range services {
+		pods, err := k.listMythicPods(ctx, clientset, service)
+		if err != nil {
+			log.Printf("failed to check status: %s", err.Error())
+			continue
+		}
+		var podStatuses []string
+		for _, pod := range pods {
+			ready, total, restarts := getPodReadiness(pod)
+			podStatuses = append(podStatuses, fmt.Sprintf("%s\t%s\t%d/%d ready\t%d restarts", pod.Name, pod.Status.Phase, ready, total, restarts))
+		}
+		log.Printf("%s:\n%s\n\n", service, strings.Join(podStatuses, "\n"))
+	}
+}
+
+// BuildUI builds a new instance of the React UI inside the mythic_react pod
+func (k *KubernetesManager) BuildUI() error {
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	err := k.runInService(ctx, "mythic_react", []string{"/bin/sh", "-c", "npm run react-build"}, nil, nil)
+	if err != nil {
+		log.Printf("[-] Failed to build new UI from MythicReactUI: %v\n", err)
+	}
+	return err
+}
+
+// GetLogs streams the logs from a pod of the service's deployment to w
+func (k *KubernetesManager) GetLogs(w io.Writer, service string, logCount int, follow bool, options *LogOptions) {
+	output := newLogFilterWriter(w, options.getFilter())
+	if err := k.streamServiceLogs(output, service, logCount, follow, options); err != nil {
+		log.Printf("[-] Failed to get logs for %s: %v\n", service, err)
+	}
+	output.Flush()
+}
+
+// GetLogsMulti streams the logs from several deployments to w at once, prefixing each line with the service name
+func (k *KubernetesManager) GetLogsMulti(w io.Writer, services []string, logCount int, follow bool, options *LogOptions) {
+	outputLock := &sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for i, service := range services {
+		wg.Add(1)
+		go func(service string, color int) {
+			defer wg.Done()
+			prefixOutput := newLogPrefixWriter(w, outputLock, service, color)
+			output := newLogFilterWriter(prefixOutput, options.getFilter())
+			if err := k.streamServiceLogs(output, service, logCount, follow, options); err != nil {
+				log.Printf("[-] Failed to get logs for %s: %v\n", service, err)
+			}
+			output.Flush()
+			prefixOutput.Flush()
+		}(service, i)
+	}
+	wg.Wait()
+}
+
+// streamServiceLogs copies the logs of one of the service's pods to w until they end, or until Ctrl-C when following them
+func (k *KubernetesManager) streamServiceLogs(w io.Writer, service string, logCount int, follow bool, options *LogOptions) error {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	pod, err := k.getServicePod(ctx, clientset, service)
+	if err != nil {
+		return err
+	}
+	logOptions := getKubernetesLogOptions(service, logCount, follow, options)
+	stream, err := clientset.CoreV1().Pods(k.Namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	if _, err = io.Copy(w, stream); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// getKubernetesLogOptions converts the log settings into pod log options, fetching all logs if logCount is 0
+func getKubernetesLogOptions(service string, logCount int, follow bool, options *LogOptions) *corev1.PodLogOptions {
+	logOptions := &corev1.PodLogOptions{
+		Container: getKubernetesName(service),
+		Follow:    follow,
+	}
+	if logCount > 0 {
+		tailLines := int64(logCount)
+		logOptions.TailLines = &tailLines
+	}
+	if options == nil {
+		return logOptions
+	}
+	logOptions.Timestamps = options.Timestamps
+	if options.Since != "" {
+		if duration, err := time.ParseDuration(options.Since); err == nil {
+			// the API only accepts whole seconds, and at least one of them
+			sinceSeconds := int64(duration.Seconds())
+			if sinceSeconds < 1 {
+				sinceSeconds = 1
+			}
+			logOptions.SinceSeconds = &sinceSeconds
+		} else if since, err := time.Parse(time.RFC3339, options.Since); err == nil {
+			sinceTime := metav1.NewTime(since)
+			logOptions.SinceTime = &sinceTime
+		} else {
+			log.Printf("[-] Ignoring since %s, expected a timestamp like 2024-01-02T15:04:05Z or a duration like 42m\n", options.Since)
+		}
+	}
+	if options.Until != "" {
+		log.Printf("[-] Kubernetes doesn't support limiting logs with until, ignoring it\n")
+	}
+	return logOptions
+}
+
+// TestPorts is a no-op since services are exposed inside the cluster rather than on this host
+func (k *KubernetesManager) TestPorts(services []string) {
+
+}
+
+func (k *KubernetesManager) GetConnectionInfo(showSecrets bool) []ConnectionInfo {
+	return k.compose.GetConnectionInfo(showSecrets)
+}
+
+func (k *KubernetesManager) ConnectionInfoJSON(w io.Writer, showSecrets bool) error {
+	return k.compose.ConnectionInfoJSON(w, showSecrets)
+}
+
+// GetPublishedPorts returns the ports of Mythic's Kubernetes Services. They're published on the Service's cluster IP rather than on this host.
+func (k *KubernetesManager) GetPublishedPorts() ([]PortMapping, error) {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	serviceList, err := clientset.CoreV1().Services(k.Namespace).List(ctx, metav1.ListOptions{LabelSelector: kubernetesPartOfSelector})
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("[-] Failed to get service list: %v\n", err))
+	}
+	sort.Slice(serviceList.Items, func(i, j int) bool {
+		return serviceList.Items[i].Labels["name"] < serviceList.Items[j].Labels["name"]
+	})
+	ports := []PortMapping{}
+	for _, kubernetesService := range serviceList.Items {
+		for _, port := range kubernetesService.Spec.Ports {
+			ports = append(ports, PortMapping{
+				Service:       kubernetesService.Labels["name"],
+				HostPort:      strconv.Itoa(int(port.Port)),
+				ContainerPort: port.TargetPort.String(),
+				Protocol:      strings.ToLower(string(port.Protocol)),
+				BindAddress:   kubernetesService.Spec.ClusterIP,
+			})
+		}
+	}
+	return ports, nil
+}
+
+func (k *KubernetesManager) PrintConnectionInfo(showSecrets bool) {
+	k.compose.PrintConnectionInfo(showSecrets)
+}
+
+// InspectService returns the details of a pod of the service's deployment, preferring a running one
+func (k *KubernetesManager) InspectService(service string) (ServiceInspect, error) {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return ServiceInspect{}, err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	pod, err := k.getServicePod(ctx, clientset, service)
+	if err != nil {
+		return ServiceInspect{}, err
+	}
+	return newKubernetesServiceInspect(service, pod), nil
+}
+
+// newKubernetesServiceInspect curates a pod's details for a service, redacting secrets out of the environment
+func newKubernetesServiceInspect(service string, pod *corev1.Pod) ServiceInspect {
+	inspect := ServiceInspect{
+		Service:       service,
+		ContainerID:   pod.Name,
+		State:         strings.ToLower(string(pod.Status.Phase)),
+		Health:        "none",
+		RestartPolicy: string(pod.Spec.RestartPolicy),
+	}
+	pod = pod.DeepCopy()
+	containerIndex := -1
+	for i, container := range pod.Spec.Containers {
+		if container.Name == getKubernetesName(service) {
+			containerIndex = i
+			break
+		}
+	}
+	if containerIndex < 0 {
+		inspect.Raw = pod
+		return inspect
+	}
+	container := &pod.Spec.Containers[containerIndex]
+	inspect.Image = container.Image
+	var env []string
+	for _, envVar := range container.Env {
+		env = append(env, envVar.Name+"="+envVar.Value)
+	}
+	inspect.Env = redactContainerEnv(env, config.GetSecretSettingValues())
+	for i := range container.Env {
+		_, container.Env[i].Value, _ = strings.Cut(inspect.Env[i], "=")
+	}
+	if container.ReadinessProbe != nil {
+		inspect.Health = "unhealthy"
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name == container.Name && containerStatus.Ready {
+				inspect.Health = "healthy"
+			}
+		}
+	}
+	claims := map[string]string{}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil {
+			claims[volume.Name] = volume.PersistentVolumeClaim.ClaimName
+		}
+	}
+	for _, volumeMount := range container.VolumeMounts {
+		if claimName, ok := claims[volumeMount.Name]; ok {
+			inspect.Mounts = append(inspect.Mounts, ServiceMount{
+				Type:        "persistentVolumeClaim",
+				Source:      claimName,
+				Destination: volumeMount.MountPath,
+				ReadOnly:    volumeMount.ReadOnly,
+			})
+		}
+	}
+	for _, port := range container.Ports {
+		inspect.Ports = append(inspect.Ports, fmt.Sprintf("%s:%d/%s", pod.Status.PodIP, port.ContainerPort, strings.ToLower(string(port.Protocol))))
+	}
+	sort.Strings(inspect.Ports)
+	if cpus, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+		inspect.CPUs = cpus.AsApproximateFloat64()
+	}
+	if memory, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+		inspect.MemoryMB = memory.Value() / (1024 * 1024)
+	}
+	inspect.Raw = pod
+	return inspect
+}
+
+// GetContainerEnv returns the environment variables the service's pod was started with, secrets are redacted
+func (k *KubernetesManager) GetContainerEnv(service string) (map[string]string, error) {
+	inspect, err := k.InspectService(service)
+	if err != nil {
+		return nil, err
+	}
+	return getContainerEnvMap(inspect.Env, config.GetSecretSettingValues()), nil
+}
+
+func (k *KubernetesManager) SetServiceEnvOverride(service string, key string, value string) error {
+	return k.compose.SetServiceEnvOverride(service, key, value)
+}
+
+func (k *KubernetesManager) RemoveServiceEnvOverride(service string, key string) error {
+	return k.compose.RemoveServiceEnvOverride(service, key)
+}
+
+// Doctor checks that the cluster is reachable with the kubeconfig, the namespace exists, and the docker-compose file used for the service definitions is valid
+func (k *KubernetesManager) Doctor() []Diagnostic {
+	var diagnostics []Diagnostic
+	version, err := k.GetManagerVersion()
+	if err != nil {
+		diagnostics = append(diagnostics, Diagnostic{
+			Name:    "cluster",
+			Status:  DiagnosticFail,
+			Message: fmt.Sprintf("Failed to connect to the cluster: %v", err),
+			Hint:    "Check your current kubeconfig context, or kubernetes_kubeconfig and kubernetes_context in .env",
+		})
+		return append(diagnostics, k.compose.doctorComposeFile())
+	}
+	diagnostics = append(diagnostics, Diagnostic{Name: "cluster", Status: DiagnosticPass, Message: fmt.Sprintf("Connected to Kubernetes %s", version)})
+	clientset, err := k.getClientset()
+	if err == nil {
+		ctx, cancel := getInterruptContext()
+		_, err = clientset.CoreV1().Namespaces().Get(ctx, k.Namespace, metav1.GetOptions{})
+		cancel()
+	}
+	if err != nil {
+		diagnostics = append(diagnostics, Diagnostic{
+			Name:    "namespace",
+			Status:  DiagnosticFail,
+			Message: fmt.Sprintf("Failed to get namespace %s: %v", k.Namespace, err),
+			Hint:    "Make sure the kubernetes_namespace in .env exists and you're allowed to use it",
+		})
+	} else {
+		diagnostics = append(diagnostics, Diagnostic{Name: "namespace", Status: DiagnosticPass, Message: fmt.Sprintf("Namespace %s is reachable", k.Namespace)})
+	}
+	return append(diagnostics, k.compose.doctorComposeFile())
+}
+
+// DumpEffectiveConfig writes the same configuration as the docker manager, since the deployments are generated from it
+func (k *KubernetesManager) DumpEffectiveConfig(w io.Writer, format string, showSecrets bool) error {
+	return k.compose.DumpEffectiveConfig(w, format, showSecrets)
+}
+
+// OverallHealth returns true only if every Mythic and installed service has all of its pods ready
+func (k *KubernetesManager) OverallHealth() (bool, map[string]string, error) {
+	services, err := config.GetIntendedMythicServiceNames()
+	if err != nil {
+		return false, nil, err
+	}
+	installedServices, err := k.GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return false, nil, err
+	}
+	services = append(services, installedServices...)
+	clientset, err := k.getClientset()
+	if err != nil {
+		return false, nil, err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	pods, err := k.listMythicPods(ctx, clientset, "")
+	if err != nil {
+		return false, nil, err
+	}
+	healthy := true
+	details := make(map[string]string, len(services))
+	for _, service := range services {
+		details[service] = "missing"
+		for _, pod := range pods {
+			if pod.Labels["name"] != service {
+				continue
+			}
+			details[service] = "healthy"
+			if pod.Status.Phase != corev1.PodRunning {
+				details[service] = strings.ToLower(string(pod.Status.Phase))
+			} else if ready, total, _ := getPodReadiness(pod); ready < total {
+				details[service] = "unhealthy"
+			}
+			break
+		}
+		if details[service] != "healthy" {
+			healthy = false
+		}
+	}
+	return healthy, details, nil
+}
+
+// Status prints out the state of all the pods for Mythic in the cluster
+func (k *KubernetesManager) Status(verbose bool, filter string) {
+	services, err := k.getPodStatuses(filter)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "SERVICE NAME\tPOD\tSTATE\tREADY\tRESTARTS")
+	for _, service := range services {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", service.Name, service.pod, service.ContainerStatus, strings.TrimSuffix(service.Health, " ready"), service.restarts)
+	}
+	fmt.Fprintln(w, "\t\t\t\t")
+	w.Flush()
+}
+
+// GetOrphanedContainers returns the Mythic Deployments whose service isn't a Mythic service, in docker-compose, or on disk anymore
+func (k *KubernetesManager) GetOrphanedContainers() ([]string, error) {
+	elementsOnDisk, err := k.GetInstalled3rdPartyServicesOnDisk()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("[-] Failed to get list of installed services on disk: %v\n", err))
+	}
+	elementsInCompose, err := k.GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("[-] Failed to get list of installed services in docker-compose: %v\n", err))
+	}
+	clientset, err := k.getClientset()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	deployments, err := clientset.AppsV1().Deployments(k.Namespace).List(ctx, metav1.ListOptions{LabelSelector: kubernetesPartOfSelector})
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("[-] Failed to get deployment list: %v\n", err))
+	}
+	serviceLabels := map[string]string{}
+	for _, deployment := range deployments.Items {
+		serviceLabels[deployment.Name] = deployment.Labels["name"]
+	}
+	knownServices := append(append(append([]string{}, config.MythicPossibleServices...), elementsInCompose...), elementsOnDisk...)
+	return findOrphanedKubernetesResources(serviceLabels, knownServices), nil
+}
+
+// findOrphanedKubernetesResources returns the sorted names of the resources (mapped to the service they were created for) whose service isn't known
+func findOrphanedKubernetesResources(serviceLabels map[string]string, knownServices []string) []string {
+	known := newServiceSet(knownServices)
+	orphans := []string{}
+	for name, service := range serviceLabels {
+		if !known[strings.ToLower(service)] {
+			orphans = append(orphans, name)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// RemoveOrphanedContainers deletes every Deployment from GetOrphanedContainers and reports the ones that failed
+func (k *KubernetesManager) RemoveOrphanedContainers() error {
+	orphans, err := k.GetOrphanedContainers()
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		return nil
+	}
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	var failures []string
+	for _, name := range orphans {
+		err = clientset.AppsV1().Deployments(k.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			failures = append(failures, fmt.Sprintf("[-] %s: %v\n", name, err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(fmt.Sprintf("[-] Failed to remove orphaned deployments:\n%s", strings.Join(failures, "")))
+	}
+	utils.LogInfo("[+] Removed orphaned deployments: %s\n", strings.Join(orphans, ", "))
+	return nil
+}
+
+// FindOrphans reports the PersistentVolumeClaims and installed service folders that don't belong to any service in docker-compose, nothing is removed.
+// The cluster's nodes manage their own images, so no images are reported.
+func (k *KubernetesManager) FindOrphans() ([]string, []string, []string, error) {
+	elementsOnDisk, err := k.GetInstalled3rdPartyServicesOnDisk()
+	if err != nil {
+		return nil, nil, nil, errors.New(fmt.Sprintf("[-] Failed to get list of installed services on disk: %v\n", err))
+	}
+	elementsInCompose, err := k.GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return nil, nil, nil, errors.New(fmt.Sprintf("[-] Failed to get list of installed services in docker-compose: %v\n", err))
+	}
+	mythicInCompose, err := k.GetCurrentMythicServiceNames()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	composeServices := append(append([]string{}, mythicInCompose...), elementsInCompose...)
+	clientset, err := k.getClientset()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	claims, err := clientset.CoreV1().PersistentVolumeClaims(k.Namespace).List(ctx, metav1.ListOptions{LabelSelector: kubernetesPartOfSelector})
+	if err != nil {
+		return nil, nil, nil, errors.New(fmt.Sprintf("[-] Failed to get volume list: %v\n", err))
+	}
+	serviceLabels := map[string]string{}
+	for _, claim := range claims.Items {
+		serviceLabels[claim.Name] = claim.Labels["name"]
+	}
+	return findOrphanedKubernetesResources(serviceLabels, composeServices),
+		[]string{},
+		findOrphanedFolders(elementsOnDisk, composeServices),
+		nil
+}
+
+// MissingImages returns the Mythic and installed services in docker-compose whose images aren't in the registry the cluster pulls from
+func (k *KubernetesManager) MissingImages() ([]MissingImage, error) {
+	installedServices, err := k.GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return nil, err
+	}
+	mythicServices, err := k.GetCurrentMythicServiceNames()
+	if err != nil {
+		return nil, err
+	}
+	curConfig := k.compose.readInDockerComposeWithOverride()
+	missing := []MissingImage{}
+	for _, service := range append(mythicServices, installedServices...) {
+		if k.DoesImageExist(service) {
+			continue
+		}
+		serviceImage := getServiceImage(curConfig, service)
+		if image, err := getKubernetesImage(curConfig, service); err == nil && image != "" {
+			serviceImage.Image = image
+		}
+		missing = append(missing, serviceImage)
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].Service < missing[j].Service
+	})
+	return missing, nil
+}
+
+// kubernetesServiceInfo is a ServiceInfo for one pod along with the pod details Status prints
+type kubernetesServiceInfo struct {
+	ServiceInfo
+	pod      string
+	restarts int32
+}
+
+// GetServiceStatuses returns the state of each Mythic pod in the cluster that matches filter,
+// followed by the services that match it without a pod the same way the docker manager does
+func (k *KubernetesManager) GetServiceStatuses(filter string) ([]ServiceInfo, error) {
+	podStatuses, err := k.getPodStatuses(filter)
+	if err != nil {
+		return nil, err
+	}
+	elementsOnDisk, err := k.GetInstalled3rdPartyServicesOnDisk()
+	if err != nil {
+		return nil, err
+	}
+	elementsInCompose, err := k.GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return nil, err
+	}
+	mythicInCompose, err := k.GetCurrentMythicServiceNames()
+	if err != nil {
+		return nil, err
+	}
+	services := []ServiceInfo{}
+	shownServices := map[string]bool{}
+	for _, podStatus := range podStatuses {
+		podStatus.OnDisk = utils.StringInSlice(podStatus.Name, elementsOnDisk)
+		services = append(services, podStatus.ServiceInfo)
+		shownServices[podStatus.Name] = true
+	}
+	return appendServicesWithoutContainers(services, shownServices, filter,
+		append(append([]string{}, mythicInCompose...), elementsInCompose...), elementsOnDisk, k.DoesImageExist), nil
+}
+
+// getPodStatuses returns the state of each Mythic pod in the cluster that matches filter, sorted by service
+func (k *KubernetesManager) getPodStatuses(filter string) ([]kubernetesServiceInfo, error) {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	pods, err := k.listMythicPods(ctx, clientset, "")
+	if err != nil {
+		return nil, err
+	}
+	services := []kubernetesServiceInfo{}
+	for _, pod := range pods {
+		if !matchesServiceFilter(pod.Labels["name"], filter) {
+			continue
+		}
+		ready, total, restarts := getPodReadiness(pod)
+		services = append(services, kubernetesServiceInfo{
+			ServiceInfo: ServiceInfo{
+				Name:            pod.Labels["name"],
+				InCompose:       true,
+				ImageBuilt:      true,
+				ContainerStatus: string(pod.Status.Phase),
+				State:           strings.ToLower(string(pod.Status.Phase)),
+				Health:          fmt.Sprintf("%d/%d ready", ready, total),
+			},
+			pod:      pod.Name,
+			restarts: restarts,
+		})
+	}
+	return services, nil
+}
+
+// ListServices returns all the 3rd party services on disk and in docker-compose and if they're running in the cluster
+func (k *KubernetesManager) ListServices() ([]ServiceInfo, error) {
+	elementsOnDisk, err := k.GetInstalled3rdPartyServicesOnDisk()
+	if err != nil {
+		return nil, err
+	}
+	elementsInCompose, err := k.GetAllInstalled3rdPartyServiceNames()
+	if err != nil {
+		return nil, err
+	}
+	allServices := append([]string{}, elementsInCompose...)
+	for _, service := range elementsOnDisk {
+		if !utils.StringInSlice(service, allServices) {
+			allServices = append(allServices, service)
+		}
+	}
+	sort.Strings(allServices)
+	services := []ServiceInfo{}
+	for _, service := range allServices {
+		info := ServiceInfo{
+			Name:       service,
+			InCompose:  utils.StringInSlice(service, elementsInCompose),
+			OnDisk:     utils.StringInSlice(service, elementsOnDisk),
+			ImageBuilt: k.DoesImageExist(service),
+		}
+		if k.IsServiceRunning(service) {
+			info.ContainerStatus = "running"
+		}
+		services = append(services, info)
+	}
+	return services, nil
+}
+
+// PrintAllServices prints out all the 3rd party services on disk and in docker-compose and if they're running
+func (k *KubernetesManager) PrintAllServices() {
+	services, err := k.ListServices()
+	if err != nil {
+		log.Fatalf("[-] Failed to get list of installed services: %v\n", err)
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "Name\tRunning\tImageInRegistry\tDockerComposeEntry")
+	for _, service := range services {
+		fmt.Fprintln(w, fmt.Sprintf("%s\t%v\t%v\t%v", service.Name, service.ContainerStatus == "running", service.ImageBuilt, service.InCompose))
+	}
+	w.Flush()
+}
+
+// ResetDatabase deletes the mythic_postgres Deployment and its PersistentVolumeClaim so the database starts fresh the next time it's started.
+// The database always lives in a PersistentVolumeClaim in the cluster, so useVolume doesn't matter.
+func (k *KubernetesManager) ResetDatabase(useVolume bool) {
+	if err := k.RemoveContainers([]string{"mythic_postgres"}); err != nil {
+		log.Printf("[-] Failed to remove database:\n%v\n", err)
+		return
+	}
+	if err := k.RemoveVolume("mythic_postgres_volume", true); err != nil {
+		log.Printf("[-] Failed to remove database:\n%v\n", err)
+	}
+}
+
+// BackupDatabase streams a pg_dump from the mythic_postgres pod to backupPath, useVolume doesn't matter since the database is always in the cluster
+func (k *KubernetesManager) BackupDatabase(backupPath string, useVolume bool) error {
+	backupFile, err := os.Create(backupPath)
+	if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to create %s: %v\n", backupPath, err))
+	}
+	utils.LogInfo("[*] Staring to dump the database, this might take a minute...")
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	// the password is an argument to the shell rather than part of the script, so it isn't in any error output
+	err = k.runInService(ctx, "mythic_postgres", []string{"/bin/sh", "-c",
+		`PGPASSWORD="$1" pg_dump -n public --format=tar -U mythic_user mythic_db`,
+		"sh", config.GetMythicEnv().GetString("postgres_password")}, nil, backupFile)
+	backupFile.Close()
+	if err != nil {
+		os.Remove(backupPath)
+		return errors.New(fmt.Sprintf("[-] Failed to dump the database: %v\n", err))
+	}
+	utils.LogInfo("[+] Successfully copied database files from volume")
+	return nil
+}
+
+// RestoreDatabase copies a pg_dump tar into the mythic_postgres pod and restores it with pg_restore
+func (k *KubernetesManager) RestoreDatabase(backupPath string, useVolume bool) error {
+	dumpPath := "/tmp/dump.tar"
+	if err := k.CopyIntoContainer("mythic_postgres", backupPath, dumpPath); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Successfully copied database files")
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	err := k.runInService(ctx, "mythic_postgres", []string{"/bin/sh", "-c",
+		`PGPASSWORD="$1" pg_restore -U mythic_user -n public --clean --if-exists -d mythic_db "$2"; status=$?; rm -f "$2"; exit $status`,
+		"sh", config.GetMythicEnv().GetString("postgres_password"), dumpPath}, nil, nil)
+	if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to restore the database: %v\n", err))
+	}
+	return nil
+}
+
+// BackupFiles copies Mythic's uploads/downloads out of the mythic_server pod's volume
+func (k *KubernetesManager) BackupFiles(backupPath string, useVolume bool) error {
+	if err := k.CopyDirFromVolume("mythic_server_volume", "", backupPath); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Successfully copied Mythic's uploads/downloads from volume")
+	return nil
+}
+
+// RestoreFiles copies Mythic's uploads/downloads back into the mythic_server pod's volume
+func (k *KubernetesManager) RestoreFiles(backupPath string, useVolume bool) error {
+	if err := k.CopyDirIntoVolume(backupPath, "", "mythic_server_volume"); err != nil {
+		return err
+	}
+	utils.LogInfo("[+] Successfully copied Mythic's uploads/downloads")
+	return nil
+}
+
+// GetVolumeInformation returns the PersistentVolumeClaims used by Mythic
+func (k *KubernetesManager) GetVolumeInformation() ([]VolumeInfo, error) {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	claims, err := clientset.CoreV1().PersistentVolumeClaims(k.Namespace).List(ctx, metav1.ListOptions{LabelSelector: kubernetesPartOfSelector})
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("[-] Failed to get volume list: %v\n", err))
+	}
+	sort.Slice(claims.Items, func(i, j int) bool {
+		return claims.Items[i].Name < claims.Items[j].Name
+	})
+	volumeInfo := []VolumeInfo{}
+	for _, claim := range claims.Items {
+		info := VolumeInfo{
+			Name:       claim.Name,
+			SizeBytes:  -1,
+			Container:  claim.Labels["name"],
+			Status:     string(claim.Status.Phase),
+			Mountpoint: claim.Spec.VolumeName,
+		}
+		if capacity, ok := claim.Status.Capacity[corev1.ResourceStorage]; ok {
+			info.SizeBytes = capacity.Value()
+		}
+		volumeInfo = append(volumeInfo, info)
+	}
+	return volumeInfo, nil
+}
+
+func (k *KubernetesManager) GetServiceDiskUsage() ([]ServiceDiskUsage, error) {
+	return nil, errKubernetesNotSupported("per service disk usage")
+}
+
+// PrintVolumeInformation prints out the PersistentVolumeClaims used by Mythic
+func (k *KubernetesManager) PrintVolumeInformation() {
+	volumes, err := k.GetVolumeInformation()
+	if err != nil {
+		log.Fatalf("[-] Failed to get volumes: %v\n", err)
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "VOLUME\tSERVICE\tSTATUS\tCAPACITY\tPERSISTENT VOLUME")
+	for _, volume := range volumes {
+		capacity := "unknown"
+		if volume.SizeBytes >= 0 {
+			capacity = units.BytesSize(float64(volume.SizeBytes))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", volume.Name, volume.Container, volume.Status, capacity, volume.Mountpoint)
+	}
+	w.Flush()
+}
+
+// RemoveVolume deletes the PersistentVolumeClaim for the named volume
+func (k *KubernetesManager) RemoveVolume(volumeName string, force bool) error {
+	if !force && !config.AskConfirm(fmt.Sprintf("Are you sure you want to delete %s and all of its contents? ", volumeName)) {
+		return errors.New("volume removal cancelled")
+	}
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	err = clientset.CoreV1().PersistentVolumeClaims(k.Namespace).Delete(ctx, getKubernetesName(volumeName), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return newManagerError(ErrVolumeNotFound, err, "[-] Volume %s doesn't exist\n", volumeName)
+	} else if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to remove volume %s: %v\n", volumeName, err))
+	}
+	utils.LogInfo("[+] Removed volume %s\n", volumeName)
+	return nil
+}
+
+// ScaleService saves the scale in docker-compose for future deploys and scales the running Deployment
+func (k *KubernetesManager) ScaleService(service string, replicas int) error {
+	service = strings.ToLower(service)
+	if err := validateServiceScale(service, replicas); err != nil {
+		return err
+	}
+	pStruct, err := k.compose.GetRawServiceConfiguration(service)
+	if err != nil {
+		return err
+	}
+	if len(pStruct) == 0 {
+		return errors.New(fmt.Sprintf("[-] %s isn't in docker-compose", service))
+	}
+	if replicas > 1 {
+		pStruct["scale"] = replicas
+	} else {
+		delete(pStruct, "scale")
+	}
+	if err = k.compose.SetServiceConfiguration(service, pStruct); err != nil {
+		return err
+	}
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	return k.scaleDeployment(ctx, clientset, service, int32(replicas))
+}
+
+// RemoveVolumes deletes the PersistentVolumeClaims for all the named volumes, continuing past failures
+func (k *KubernetesManager) RemoveVolumes(volumeNames []string) error {
+	if len(volumeNames) == 0 {
+		return nil
+	}
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	var failures []string
+	for _, volumeName := range volumeNames {
+		err = clientset.CoreV1().PersistentVolumeClaims(k.Namespace).Delete(ctx, getKubernetesName(volumeName), metav1.DeleteOptions{})
+		if err == nil {
+			utils.LogInfo("[+] Removed volume %s\n", volumeName)
+		} else if !apierrors.IsNotFound(err) {
+			failures = append(failures, fmt.Sprintf("[-] %s: %v\n", volumeName, err))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(fmt.Sprintf("[-] Failed to remove volumes:\n%s", strings.Join(failures, "")))
+	}
+	return nil
+}
+
+// RemoveServiceVolumes deletes the PersistentVolumeClaims for the service's volumes in docker-compose
+func (k *KubernetesManager) RemoveServiceVolumes(service string) error {
+	composeVolumes, err := k.compose.GetVolumes()
+	if err != nil {
+		return err
+	}
+	var volumeNames []string
+	for volumeName := range composeVolumes {
+		volumeNames = append(volumeNames, volumeName)
+	}
+	serviceVolumes := getServiceVolumeNames(volumeNames, service)
+	if err = k.RemoveVolumes(serviceVolumes); err != nil {
+		return err
+	}
+	k.compose.removeServiceVolumesFromCompose(serviceVolumes)
+	return nil
+}
+
+// CopyIntoVolume copies sourceFile to destinationFileName (relative to the root of the volume) through a running pod that mounts the volume
+func (k *KubernetesManager) CopyIntoVolume(sourceFile string, destinationFileName string, destinationVolume string) error {
+	service, mountPath, err := k.getVolumeMount(destinationVolume)
+	if err != nil {
+		return err
+	}
+	return k.CopyIntoContainer(service, sourceFile, path.Join(mountPath, destinationFileName))
+}
+
+// CopyFromVolume copies sourceFileName (relative to the root of the volume) to destinationName through a running pod that mounts the volume
+func (k *KubernetesManager) CopyFromVolume(sourceVolumeName string, sourceFileName string, destinationName string) error {
+	service, mountPath, err := k.getVolumeMount(sourceVolumeName)
+	if err != nil {
+		return err
+	}
+	return k.CopyFromContainer(service, path.Join(mountPath, sourceFileName), destinationName)
+}
+
+// CopyFilePathIntoVolume copies a single local file to destinationFileName (relative to the root of the volume) through a running pod that mounts the volume
+func (k *KubernetesManager) CopyFilePathIntoVolume(localPath string, destinationFileName string, destinationVolume string) error {
+	localFileInfo, err := os.Stat(localPath)
+	if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to get information about %s: %v\n", localPath, err))
+	}
+	if !localFileInfo.Mode().IsRegular() {
+		return errors.New(fmt.Sprintf("[-] %s isn't a regular file, use CopyDirIntoVolume for directories\n", localPath))
+	}
+	return k.CopyIntoVolume(localPath, destinationFileName, destinationVolume)
+}
+
+// CopyDirIntoVolume recursively copies the contents of sourceDir into destinationDir (relative to the root of the volume)
+func (k *KubernetesManager) CopyDirIntoVolume(sourceDir string, destinationDir string, destinationVolume string) error {
+	if info, err := os.Stat(sourceDir); err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to find %s: %v\n", sourceDir, err))
+	} else if !info.IsDir() {
+		return errors.New(fmt.Sprintf("[-] %s isn't a directory\n", sourceDir))
+	}
+	service, mountPath, err := k.getVolumeMount(destinationVolume)
+	if err != nil {
+		return err
+	}
+	utils.LogInfo("[*] Staring to copy %s to %s, this might take a minute...", sourceDir, destinationVolume)
+	err = k.copyArchiveIntoService(service, mountPath, func(w io.Writer) error {
+		return tarDirectory(sourceDir, destinationDir, w)
+	})
+	if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to copy %s into %s: %v\n", sourceDir, destinationVolume, err))
+	}
+	return nil
+}
+
+// CopyDirFromVolume recursively copies the contents of sourceDir (relative to the root of the volume) into destinationDir
+func (k *KubernetesManager) CopyDirFromVolume(sourceVolumeName string, sourceDir string, destinationDir string) error {
+	service, mountPath, err := k.getVolumeMount(sourceVolumeName)
+	if err != nil {
+		return err
+	}
+	utils.LogInfo("[*] Staring to copy from %s to %s, this might take a minute...", sourceVolumeName, destinationDir)
+	return k.copyArchiveFromService(service, path.Join(mountPath, sourceDir), destinationDir, true)
+}
+
+func (k *KubernetesManager) SnapshotVolume(volumeName string) (string, error) {
+	return "", errKubernetesNotSupported("volume snapshots")
+}
+
+func (k *KubernetesManager) RestoreVolumeSnapshot(volumeName string, snapshotID string) error {
+	return errKubernetesNotSupported("volume snapshots")
+}
+
+func (k *KubernetesManager) CloneVolume(sourceVolume string, destinationVolume string) error {
+	return errKubernetesNotSupported("cloning volumes")
+}
+
+func (k *KubernetesManager) ListVolumeSnapshots() ([]VolumeSnapshot, error) {
+	return nil, errKubernetesNotSupported("volume snapshots")
+}
+
+// CopyIntoContainer copies a local file or directory to containerPath in a running pod of the service with tar, like kubectl cp.
+// A directory is copied as containerPath, the same way docker cp does it.
+func (k *KubernetesManager) CopyIntoContainer(service string, localPath string, containerPath string) error {
+	if !path.IsAbs(containerPath) {
+		return errors.New(fmt.Sprintf("[-] %s needs to be an absolute path in the container\n", containerPath))
+	}
+	localFileInfo, err := os.Stat(localPath)
+	if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to find %s: %v\n", localPath, err))
+	}
+	if !localFileInfo.IsDir() && !localFileInfo.Mode().IsRegular() {
+		return errors.New(fmt.Sprintf("[-] %s isn't a regular file or directory\n", localPath))
+	}
+	containerPath = path.Clean(containerPath)
+	utils.LogInfo("[*] Copying %s to %s:%s", localPath, service, containerPath)
+	// the archive is extracted into the parent folder with an entry named after the destination
+	err = k.copyArchiveIntoService(service, path.Dir(containerPath), func(w io.Writer) error {
+		if localFileInfo.IsDir() {
+			return tarDirectory(localPath, path.Base(containerPath), w)
+		}
+		localFile, err := os.Open(localPath)
+		if err != nil {
+			return err
+		}
+		defer localFile.Close()
+		return tarFile(localFile, localFileInfo, path.Base(containerPath), w)
+	})
+	if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to copy %s into %s: %v\n", localPath, service, err))
+	}
+	return nil
+}
+
+// CopyFromContainer copies a file or directory at containerPath in a running pod of the service to localPath the same way docker cp does
+func (k *KubernetesManager) CopyFromContainer(service string, containerPath string, localPath string) error {
+	utils.LogInfo("[*] Copying %s:%s to %s", service, containerPath, localPath)
+	return k.copyArchiveFromService(service, containerPath, localPath, false)
+}
+
+// ShellIntoService opens an interactive shell in a running pod of the service.
+// The local terminal is put in raw mode for the session and restored when the shell exits.
+func (k *KubernetesManager) ShellIntoService(service string) error {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return errors.New("[-] An interactive shell needs to be run from a terminal\n")
+	}
+	oldState, err := term.SetRawTerminal(os.Stdin.Fd())
+	if err != nil {
+		return errors.New(fmt.Sprintf("[-] Failed to set the terminal to raw mode: %v\n", err))
+	}
+	defer term.RestoreTerminal(os.Stdin.Fd(), oldState)
+	// keep the pod's terminal the same size as ours
+	sizeQueue := &terminalSizeQueue{resize: make(chan os.Signal, 1)}
+	signal.Notify(sizeQueue.resize, syscall.SIGWINCH)
+	defer func() {
+		signal.Stop(sizeQueue.resize)
+		close(sizeQueue.resize)
+	}()
+	sizeQueue.resize <- syscall.SIGWINCH
+	err = k.execInService(context.Background(), service, []string{"/bin/sh", "-c", shellCommand}, remotecommand.StreamOptions{
+		Stdin:             os.Stdin,
+		Stdout:            os.Stdout,
+		Tty:               true,
+		TerminalSizeQueue: sizeQueue,
+	})
+	var exitErr utilexec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return errors.New(fmt.Sprintf("[-] Shell in %s failed: %v\n", service, err))
+	}
+	// a non-zero exit is just the last command run in the shell failing
+	return nil
+}
+
+// terminalSizeQueue sends the local terminal's size to an exec session each time it's resized
+type terminalSizeQueue struct {
+	resize chan os.Signal
+}
+
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	for range q.resize {
+		if size, err := term.GetWinsize(os.Stdin.Fd()); err == nil {
+			return &remotecommand.TerminalSize{Width: size.Width, Height: size.Height}
+		}
+	}
+	return nil
+}
+
+// GetServicePort returns the port configured in .env since pods don't publish ports on the host
+func (k *KubernetesManager) GetServicePort(service string) (uint16, error) {
+	return getConfiguredServicePort(service)
+}
+
+// WaitForPort waits until the service's Deployment has a ready pod or timeout passes.
+// Pods don't publish ports on this host, so the pod being ready (its healthcheck's readiness probe passing, if it has one) stands in for the port accepting connections.
+func (k *KubernetesManager) WaitForPort(service string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for !k.IsServiceRunning(service) {
+		if time.Now().After(deadline) {
+			return errors.New(fmt.Sprintf("[-] Timed out after %s waiting for %s to have a ready pod\n", timeout, service))
+		}
+		utils.LogVerbose("[*] Waiting for %s to have a ready pod\n", service)
+		time.Sleep(time.Second)
+	}
+	return nil
+}
+
+// Internal Support Commands
+
+// getKubernetesClientConfig loads the kubeconfig with the same rules kubectl uses (KUBECONFIG, then ~/.kube/config, and its current context).
+// kubernetes_kubeconfig and kubernetes_context in .env pick a different file or context, a relative kubernetes_kubeconfig is relative to the Mythic folder.
+func getKubernetesClientConfig() clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig := config.GetMythicEnv().GetString("kubernetes_kubeconfig"); kubeconfig != "" {
+		if !filepath.IsAbs(kubeconfig) {
+			kubeconfig = filepath.Join(utils.GetCwdFromExe(), kubeconfig)
+		}
+		loadingRules.ExplicitPath = kubeconfig
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: config.GetMythicEnv().GetString("kubernetes_context")}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+}
+
+// getClientset returns the client for the cluster, creating it from the kubeconfig the first time
+func (k *KubernetesManager) getClientset() (kubernetes.Interface, error) {
+	k.clientsetMu.Lock()
+	defer k.clientsetMu.Unlock()
+	if k.clientset != nil {
+		return k.clientset, nil
+	}
+	restConfig, err := getKubernetesClientConfig().ClientConfig()
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("[-] Failed to load the kubeconfig: %v\n", err))
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("[-] Failed to create a Kubernetes client: %v\n", err))
+	}
+	k.restConfig = restConfig
+	k.clientset = clientset
+	return clientset, nil
+}
+
+// listMythicPods returns the pods of Mythic's deployments sorted by service and then pod name, only the service's pods if it's set
+func (k *KubernetesManager) listMythicPods(ctx context.Context, clientset kubernetes.Interface, service string) ([]corev1.Pod, error) {
+	selector := kubernetesPartOfSelector
+	if service != "" {
+		selector += ",name=" + strings.ToLower(service)
+	}
+	podList, err := clientset.CoreV1().Pods(k.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("[-] Failed to get pod list: %v\n", err))
+	}
+	sort.Slice(podList.Items, func(i, j int) bool {
+		if podList.Items[i].Labels["name"] != podList.Items[j].Labels["name"] {
+			return podList.Items[i].Labels["name"] < podList.Items[j].Labels["name"]
+		}
+		return podList.Items[i].Name < podList.Items[j].Name
+	})
+	return podList.Items, nil
+}
+
+// getServicePod returns a running pod of the service, or its first pod if none of them are running
+func (k *KubernetesManager) getServicePod(ctx context.Context, clientset kubernetes.Interface, service string) (*corev1.Pod, error) {
+	pods, err := k.listMythicPods(ctx, clientset, service)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, errors.New(fmt.Sprintf("[-] No pods found for %s\n", service))
+	}
+	for i := range pods {
+		if pods[i].Status.Phase == corev1.PodRunning && pods[i].DeletionTimestamp == nil {
+			return &pods[i], nil
+		}
+	}
+	return &pods[0], nil
+}
+
+// getPodReadiness returns how many of the pod's containers are ready, how many there are, and how many times they've restarted
+func getPodReadiness(pod corev1.Pod) (int, int, int32) {
+	ready := 0
+	var restarts int32
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Ready {
+			ready++
+		}
+		restarts += containerStatus.RestartCount
+	}
+	return ready, len(pod.Status.ContainerStatuses), restarts
+}
+
+// scaleDeployment sets the service's Deployment to run replicas pods, retrying if the Deployment changes in the meantime
+func (k *KubernetesManager) scaleDeployment(ctx context.Context, clientset kubernetes.Interface, service string, replicas int32) error {
+	deployments := clientset.AppsV1().Deployments(k.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		deployment, err := deployments.Get(ctx, getKubernetesName(service), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		deployment.Spec.Replicas = &replicas
+		_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// applyServiceManifests creates the service's resources in the cluster, or updates the ones that already exist.
+// PersistentVolumeClaims are only created since most of their spec can't change, and restart rolls the Deployment's pods like kubectl rollout restart.
+func (k *KubernetesManager) applyServiceManifests(ctx context.Context, clientset kubernetes.Interface, manifests *kubernetesManifests, restart bool) error {
+	for _, claim := range manifests.Claims {
+		_, err := clientset.CoreV1().PersistentVolumeClaims(k.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+	deployment := manifests.Deployment
+	if restart {
+		deployment.Spec.Template.Annotations = map[string]string{kubernetesRestartedAtAnnotation: time.Now().Format(time.RFC3339)}
+	}
+	deployments := clientset.AppsV1().Deployments(k.Namespace)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := deployments.Get(ctx, deployment.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = deployments.Create(ctx, deployment, metav1.CreateOptions{})
+			return err
+		} else if err != nil {
+			return err
+		}
+		// keep the last restart, otherwise applying an unchanged Deployment would roll its pods
+		if restartedAt, ok := existing.Spec.Template.Annotations[kubernetesRestartedAtAnnotation]; ok && !restart {
+			deployment.Spec.Template.Annotations = map[string]string{kubernetesRestartedAtAnnotation: restartedAt}
+		}
+		deployment.ResourceVersion = existing.ResourceVersion
+		_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil || manifests.Service == nil {
+		return err
+	}
+	kubernetesService := manifests.Service
+	services := clientset.CoreV1().Services(k.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := services.Get(ctx, kubernetesService.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = services.Create(ctx, kubernetesService, metav1.CreateOptions{})
+			return err
+		} else if err != nil {
+			return err
+		}
+		// the cluster IP is assigned when the Service is created and can't change
+		kubernetesService.Spec.ClusterIP = existing.Spec.ClusterIP
+		kubernetesService.Spec.ClusterIPs = existing.Spec.ClusterIPs
+		kubernetesService.ResourceVersion = existing.ResourceVersion
+		_, err = services.Update(ctx, kubernetesService, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// execInService runs command in a running pod of the service, streaming the stdin, stdout, and stderr in streamOptions that are set
+func (k *KubernetesManager) execInService(ctx context.Context, service string, command []string, streamOptions remotecommand.StreamOptions) error {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return err
+	}
+	pod, err := k.getServicePod(ctx, clientset, service)
+	if err != nil {
+		return err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return newManagerError(ErrContainerNotRunning, nil, "[-] %s isn't running\n", service)
+	}
+	request := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(k.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: getKubernetesName(service),
+			Command:   command,
+			Stdin:     streamOptions.Stdin != nil,
+			Stdout:    streamOptions.Stdout != nil,
+			Stderr:    streamOptions.Stderr != nil,
+			TTY:       streamOptions.Tty,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(k.restConfig, "POST", request.URL())
+	if err != nil {
+		return err
+	}
+	return executor.StreamWithContext(ctx, streamOptions)
+}
+
+// runInService runs command in a running pod of the service without a terminal, including what it wrote to stderr in the error if it fails
+func (k *KubernetesManager) runInService(ctx context.Context, service string, command []string, stdin io.Reader, stdout io.Writer) error {
+	stderr := &bytes.Buffer{}
+	err := k.execInService(ctx, service, command, remotecommand.StreamOptions{Stdin: stdin, Stdout: stdout, Stderr: stderr})
+	if err != nil && stderr.Len() > 0 {
+		return errors.New(fmt.Sprintf("%v: %s", err, strings.TrimSpace(stderr.String())))
+	}
+	return err
+}
+
+// copyArchiveIntoService extracts the tar archive from writeArchive into directory in a running pod of the service.
+// Copies can be large, so they're only cancelled by Ctrl-C.
+func (k *KubernetesManager) copyArchiveIntoService(service string, directory string, writeArchive func(w io.Writer) error) error {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeArchive(writer))
+	}()
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	err := k.runInService(ctx, service, []string{"tar", "xf", "-", "-C", directory}, reader, nil)
+	reader.Close()
+	return err
+}
+
+// copyArchiveFromService extracts a tar archive of sourcePath in a running pod of the service to destinationName, see extractVolumeArchive
+func (k *KubernetesManager) copyArchiveFromService(service string, sourcePath string, destinationName string, contentsOnly bool) error {
+	sourcePath = path.Clean(sourcePath)
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	reader, writer := io.Pipe()
+	go func() {
+		// every entry is under sourcePath's base name, like the archive docker cp makes
+		writer.CloseWithError(k.runInService(ctx, service, []string{"tar", "cf", "-", "-C", path.Dir(sourcePath), path.Base(sourcePath)}, nil, writer))
+	}()
+	err := extractVolumeArchive(reader, destinationName, contentsOnly)
+	reader.Close()
+	return err
+}
+
+// getVolumeMount finds a service whose pods mount the volume's PersistentVolumeClaim and the path it's mounted at.
+// There's no helper container to reach a volume like with Docker, so that service has to be running to copy in or out of the volume.
+func (k *KubernetesManager) getVolumeMount(volumeName string) (string, string, error) {
+	clientset, err := k.getClientset()
+	if err != nil {
+		return "", "", err
+	}
+	ctx, cancel := getInterruptContext()
+	defer cancel()
+	deployments, err := clientset.AppsV1().Deployments(k.Namespace).List(ctx, metav1.ListOptions{LabelSelector: kubernetesPartOfSelector})
+	if err != nil {
+		return "", "", errors.New(fmt.Sprintf("[-] Failed to get deployment list: %v\n", err))
+	}
+	service, mountPath := findVolumeMount(deployments.Items, getKubernetesName(volumeName))
+	if service == "" {
+		return "", "", newManagerError(ErrVolumeNotFound, nil, "[-] No service mounts volume %s\n", volumeName)
+	}
+	return service, mountPath, nil
+}
+
+// findVolumeMount returns the service of the first Deployment that mounts claimName and where it's mounted, or empty strings if none do
+func findVolumeMount(deployments []appsv1.Deployment, claimName string) (string, string) {
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].Name < deployments[j].Name
+	})
+	for _, deployment := range deployments {
+		podSpec := deployment.Spec.Template.Spec
+		for _, volume := range podSpec.Volumes {
+			if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != claimName {
+				continue
+			}
+			for _, container := range podSpec.Containers {
+				for _, volumeMount := range container.VolumeMounts {
+					if volumeMount.Name == volume.Name {
+						return deployment.Labels["name"], volumeMount.MountPath
+					}
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+func errKubernetesNotSupported(action string) error {
+	return errors.New(fmt.Sprintf("%s isn't supported by the kubernetes manager", action))
+}
+
+// getKubernetesName converts a service or volume name into a valid Kubernetes resource name
+func getKubernetesName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// isHostnameChar checks if c can be part of a hostname or service name, so partial name matches are skipped
+func isHostnameChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+}
+
+// isHostnameStart checks if a name found at index in value could be a hostname instead of part of a longer name or a file path.
+// After a '/', it's only a hostname if it's the start of a URL's host, like http://mythic_server.
+func isHostnameStart(value string, index int) bool {
+	if index == 0 {
+		return true
+	}
+	switch previous := value[index-1]; {
+	case isHostnameChar(previous) || previous == '.':
+		return false
+	case previous == '/':
+		return strings.HasSuffix(value[:index], "://")
+	default:
+		return true
+	}
+}
+
+// rewriteKubernetesHosts replaces references to docker-compose service names in value with their Kubernetes names.
+// Services talk to each other by service name (ex: MYTHIC_SERVER_HOST=mythic_server or a postgres://...@mythic_postgres URL),
+// but a Kubernetes Service can't have an underscore in its name, so those hostnames wouldn't resolve in the cluster.
+func rewriteKubernetesHosts(value string, services []string) string {
+	for _, service := range services {
+		name := getKubernetesName(service)
+		if name == service {
+			continue
+		}
+		var rewritten strings.Builder
+		start := 0
+		for {
+			index := strings.Index(value[start:], service)
+			if index < 0 {
+				break
+			}
+			index += start
+			end := index + len(service)
+			rewritten.WriteString(value[start:index])
+			if isHostnameStart(value, index) && (end == len(value) || !isHostnameChar(value[end])) {
+				rewritten.WriteString(name)
+			} else {
+				rewritten.WriteString(service)
+			}
+			start = end
+		}
+		rewritten.WriteString(value[start:])
+		value = rewritten.String()
+	}
+	return value
+}
+
+// getKubernetesImage returns the image the cluster should pull for a service.
+// Images that docker compose builds only exist on this host, so they're pulled from kubernetes_image_registry
+// with the same names './mythic-cli push' gives them.
+func getKubernetesImage(curConfig *viper.Viper, service string) (string, error) {
+	serviceKey := "services." + strings.ToLower(service)
+	if !curConfig.IsSet(serviceKey + ".build") {
+		return curConfig.GetString(serviceKey + ".image"), nil
+	}
+	registry := strings.TrimSuffix(config.GetMythicEnv().GetString("kubernetes_image_registry"), "/")
+	if registry == "" {
+		return "", errors.New(fmt.Sprintf("[-] %s is built locally, so the cluster can't pull it. Build it with './mythic-cli build --build-only %s', "+
+			"push it with './mythic-cli push <registry> %s', and set kubernetes_image_registry to that registry\n", service, service, service))
+	}
+	return fmt.Sprintf("%s/%s:latest", registry, strings.ToLower(service)), nil
+}
+
+// expandMythicEnv replaces ${VAR} references with values from the Mythic .env since Kubernetes doesn't do that for us
+func expandMythicEnv(value string) string {
+	return os.Expand(value, func(key string) string {
+		return config.GetMythicEnv().GetString(key)
+	})
+}
+
+// splitCommand splits a docker-compose command string into arguments, respecting single and double quotes
+func splitCommand(command string) []string {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+	for _, c := range command {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteRune(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// kubernetesManifests are the Kubernetes resources a docker-compose service is translated into
+type kubernetesManifests struct {
+	Claims     []*corev1.PersistentVolumeClaim
+	Deployment *appsv1.Deployment
+	// Service is nil when the docker-compose service doesn't publish any ports
+	Service *corev1.Service
+}
+
+// objects returns the manifests in the order they need to be created
+func (m *kubernetesManifests) objects() []interface{} {
+	var objects []interface{}
+	for _, claim := range m.Claims {
+		objects = append(objects, claim)
+	}
+	objects = append(objects, m.Deployment)
+	if m.Service != nil {
+		objects = append(objects, m.Service)
+	}
+	return objects
+}
+
+// getHealthcheckSeconds returns a docker-compose healthcheck duration in whole seconds, or 0 if it isn't set
+func getHealthcheckSeconds(curConfig *viper.Viper, key string) int32 {
+	duration, err := time.ParseDuration(curConfig.GetString(key))
+	if err != nil || duration <= 0 {
+		return 0
+	}
+	if duration < time.Second {
+		return 1
+	}
+	return int32(duration.Seconds())
+}
+
+// getKubernetesProbe translates the service's docker-compose healthcheck into a readiness probe, so its pods only count as ready once it passes.
+// Services without a healthcheck in docker-compose don't get a probe since Kubernetes ignores a HEALTHCHECK in the image.
+func getKubernetesProbe(curConfig *viper.Viper, serviceKey string, composeServices []string) *corev1.Probe {
+	healthcheckKey := serviceKey + ".healthcheck"
+	if !curConfig.IsSet(healthcheckKey+".test") || curConfig.GetBool(healthcheckKey+".disable") {
+		return nil
+	}
+	var command []string
+	if test, ok := curConfig.Get(healthcheckKey + ".test").(string); ok {
+		command = []string{"/bin/sh", "-c", test}
+	} else if test := curConfig.GetStringSlice(healthcheckKey + ".test"); len(test) > 1 {
+		switch test[0] {
+		case "CMD-SHELL":
+			command = []string{"/bin/sh", "-c", strings.Join(test[1:], " ")}
+		case "CMD":
+			command = test[1:]
+		}
+	}
+	if len(command) == 0 {
+		return nil
+	}
+	for i := range command {
+		command[i] = rewriteKubernetesHosts(expandMythicEnv(command[i]), composeServices)
+	}
+	return &corev1.Probe{
+		ProbeHandler:        corev1.ProbeHandler{Exec: &corev1.ExecAction{Command: command}},
+		PeriodSeconds:       getHealthcheckSeconds(curConfig, healthcheckKey+".interval"),
+		TimeoutSeconds:      getHealthcheckSeconds(curConfig, healthcheckKey+".timeout"),
+		InitialDelaySeconds: getHealthcheckSeconds(curConfig, healthcheckKey+".start_period"),
+		FailureThreshold:    int32(curConfig.GetInt(healthcheckKey + ".retries")),
+	}
+}
+
+// getServiceManifests translates a docker-compose service definition into a Deployment, Service, and PersistentVolumeClaims
+func (k *KubernetesManager) getServiceManifests(service string) (*kubernetesManifests, error) {
+	curConfig := k.compose.readInDockerComposeWithOverride()
+	serviceKey := "services." + strings.ToLower(service)
+	if !curConfig.InConfig(serviceKey) {
+		return nil, errors.New(fmt.Sprintf("[-] %s isn't in docker-compose", service))
+	}
+	name := getKubernetesName(service)
+	var composeServices []string
+	for composeService := range curConfig.GetStringMap("services") {
+		composeServices = append(composeServices, composeService)
+	}
+	image, err := getKubernetesImage(curConfig, service)
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/name":    name,
+		"app.kubernetes.io/part-of": "mythic",
+		"name":                      strings.ToLower(service),
+	}
+	objectMeta := metav1.ObjectMeta{Name: name, Namespace: k.Namespace, Labels: labels}
+	container := corev1.Container{
+		Name:           name,
+		Image:          image,
+		ReadinessProbe: getKubernetesProbe(curConfig, serviceKey, composeServices),
+	}
+	if command := curConfig.GetString(serviceKey + ".command"); command != "" {
+		container.Args = splitCommand(rewriteKubernetesHosts(expandMythicEnv(command), composeServices))
+	}
+	for _, entry := range curConfig.GetStringSlice(serviceKey + ".environment") {
+		pieces := strings.SplitN(entry, "=", 2)
+		if len(pieces) != 2 {
+			continue
+		}
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  pieces[0],
+			Value: rewriteKubernetesHosts(expandMythicEnv(pieces[1]), composeServices),
+		})
+	}
+	limits := corev1.ResourceList{}
+	if cpus := curConfig.GetFloat64(serviceKey + ".cpus"); cpus > 0 {
+		limits[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(cpus*1000), resource.DecimalSI)
+	}
+	if memLimit := curConfig.GetString(serviceKey + ".mem_limit"); memLimit != "" {
+		memoryBytes, err := units.RAMInBytes(memLimit)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("[-] Failed to parse mem_limit %s: %v", memLimit, err))
+		}
+		limits[corev1.ResourceMemory] = *resource.NewQuantity(memoryBytes, resource.BinarySI)
+	}
+	if len(limits) > 0 {
+		container.Resources.Limits = limits
+	}
+	var servicePorts []corev1.ServicePort
+	for _, port := range curConfig.GetStringSlice(serviceKey + ".ports") {
+		pieces := strings.Split(expandMythicEnv(port), ":")
+		containerPort, err := strconv.Atoi(pieces[len(pieces)-1])
+		if err != nil {
+			utils.LogInfo("[*] Skipping port %s for %s, only single port mappings are supported\n", port, service)
+			continue
+		}
+		publishedPort := containerPort
+		if len(pieces) > 1 {
+			if publishedPort, err = strconv.Atoi(pieces[len(pieces)-2]); err != nil {
+				publishedPort = containerPort
+			}
+		}
+		container.Ports = append(container.Ports, corev1.ContainerPort{ContainerPort: int32(containerPort)})
+		servicePorts = append(servicePorts, corev1.ServicePort{
+			Name:       fmt.Sprintf("port-%d", publishedPort),
+			Port:       int32(publishedPort),
+			TargetPort: intstr.FromInt32(int32(containerPort)),
+		})
+	}
+	manifests := &kubernetesManifests{}
+	podSpec := corev1.PodSpec{}
+	for _, volume := range curConfig.GetStringSlice(serviceKey + ".volumes") {
+		pieces := strings.Split(volume, ":")
+		if len(pieces) < 2 || strings.HasPrefix(pieces[0], ".") || strings.HasPrefix(pieces[0], "/") {
+			utils.LogInfo("[*] Skipping volume %s for %s, only named volumes are supported\n", volume, service)
+			continue
+		}
+		claimName := getKubernetesName(pieces[0])
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: claimName, MountPath: pieces[1]})
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: claimName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		})
+		volumeSize := config.GetMythicEnv().GetString("kubernetes_volume_size")
+		storage, err := resource.ParseQuantity(volumeSize)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("[-] Failed to parse kubernetes_volume_size %s: %v", volumeSize, err))
+		}
+		claimMeta := *objectMeta.DeepCopy()
+		claimMeta.Name = claimName
+		manifests.Claims = append(manifests.Claims, &corev1.PersistentVolumeClaim{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"},
+			ObjectMeta: claimMeta,
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: storage},
+				},
+			},
+		})
+	}
+	podSpec.Containers = []corev1.Container{container}
+	if gracePeriod := getStopGracePeriod(curConfig, service); gracePeriod > 0 {
+		terminationGracePeriodSeconds := int64(gracePeriod)
+		podSpec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
+	}
+	replicas := int32(getServiceScale(curConfig, service))
+	manifests.Deployment = &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: *objectMeta.DeepCopy(),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": name},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+	if len(servicePorts) > 0 {
+		manifests.Service = &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: *objectMeta.DeepCopy(),
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app.kubernetes.io/name": name},
+				Ports:    servicePorts,
+			},
+		}
+	}
+	return manifests, nil
+}
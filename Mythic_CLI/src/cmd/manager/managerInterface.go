@@ -3,8 +3,13 @@ package manager
 import (
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
 	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
+	"io"
 	"log"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
 )
 
 type CLIManager interface {
@@ -12,36 +17,88 @@ type CLIManager interface {
 	GetManagerName() string
 	// IsServiceRunning checks if a service by the specified name is currently running or not
 	IsServiceRunning(service string) bool
-	// CheckRequiredManagerVersion checks if the version of the management software installed is a valid version or not
-	CheckRequiredManagerVersion() bool
+	// GetManagerVersion returns the version of the management software in use, like the Docker server version
+	GetManagerVersion() (string, error)
+	// CheckRequiredManagerVersion checks if the version of the management software installed is a valid version or not,
+	// the error says which check failed and what was detected
+	CheckRequiredManagerVersion() error
 	// GenerateRequiredConfig creates any necessary base configuration files needed by the manager, like a docker-compose.yml file
 	GenerateRequiredConfig()
 	// DoesImageExist check if a local image exists for the service or if it needs to be built first
 	DoesImageExist(service string) bool
-	// RemoveImages deletes unused images from the system to help free up space
-	RemoveImages() error
+	// RemoveImages deletes unused images from the system to help free up space and returns the candidate image IDs, dryRun only reports them
+	RemoveImages(dryRun bool) ([]string, error)
+	// RemoveImagesOlderThan deletes images created before the cutoff, optionally keeping the ones used by running containers
+	RemoveImagesOlderThan(olderThan time.Duration, keepRunning bool) error
+	// Prune removes dangling images and optionally unused build cache and volumes that no longer belong to a service
+	Prune(includeVolumes bool, includeBuildCache bool) error
 	// SaveImages saves off the backing built images for the specified services
 	SaveImages(services []string, outputPath string) error
-	// LoadImages loads the images specified at the outputPath
+	// SaveImagesSeparately saves off the backing built images for the specified services into one <service>.tar each
+	SaveImagesSeparately(services []string, outputDir string) error
+	// LoadImages loads every image tar file found in the outputPath folder
 	LoadImages(outputPath string) error
+	// PushImages tags the backing images for the specified services with the registry prefix and pushes them
+	PushImages(services []string, registry string) error
+	// PullImages pulls the backing images for the specified services from the registry and tags them for local use
+	PullImages(services []string, registry string) error
 	// RemoveContainers stop existing containers and removes them completely
 	RemoveContainers(services []string) error
 	// GetVolumes returns a map of volumes and their configurations specified to be used (not necessarily what's actually created)
 	GetVolumes() (map[string]interface{}, error)
 	// SetVolumes updates the information about volumes that should be expected to exist or tracked
 	SetVolumes(map[string]interface{})
+	// GetNetworks returns a map of networks and their configurations specified to be used (not necessarily what's actually created)
+	GetNetworks() (map[string]interface{}, error)
+	// SetNetworks updates the information about networks that services can be attached to
+	SetNetworks(map[string]interface{})
+	// GetSecrets returns a map of the file based secrets that services can use
+	GetSecrets() (map[string]interface{}, error)
+	// SetSecrets updates the file based secrets that services can use
+	SetSecrets(map[string]interface{})
+	// AttachServiceToNetwork connects a service to a named network, defining it as an external network if it's not defined yet
+	AttachServiceToNetwork(service string, network string) error
 	// GetServiceConfiguration gets the current configuration for a Mythic or 3rd party service
 	GetServiceConfiguration(string) (map[string]interface{}, error)
+	// GetRawServiceConfiguration returns the service's configuration without removing any fields
+	GetRawServiceConfiguration(service string) (map[string]interface{}, error)
 	// SetServiceConfiguration sets the specified configuration for a Mythic or specified 3rd party service
 	SetServiceConfiguration(string, map[string]interface{}) error
+	// MergeServiceConfiguration updates only the CLI managed pieces of a service configuration and leaves user customizations intact
+	MergeServiceConfiguration(string, map[string]interface{}) error
+	// SetServiceResourceLimits caps the cpus and memory (in MB) a service is able to consume
+	SetServiceResourceLimits(service string, cpus float64, memoryMB int) error
+	// SetServiceLogging sets the log driver (ex: json-file, syslog, journald, fluentd) and its options for a service
+	SetServiceLogging(service string, driver string, options map[string]string) error
+	// SetServiceHealthcheck sets the healthcheck for a service, overriding the one from its image (if any)
+	SetServiceHealthcheck(service string, hc Healthcheck) error
+	// SetServicePlatform sets the platform (ex: linux/amd64) a service is built and run for, empty uses the host's
+	SetServicePlatform(service string, platform string) error
+	// GetServiceResourceLimits returns the cpus and memory (in MB) limits for a service, 0 means unlimited
+	GetServiceResourceLimits(service string) (float64, int, error)
+	// DumpEffectiveConfig writes the environment given to services and the parsed service definitions to w as yaml or json,
+	// redacting secrets unless showSecrets is true
+	DumpEffectiveConfig(w io.Writer, format string, showSecrets bool) error
+	// PrintComposeConfig writes the fully resolved service configuration to w
+	PrintComposeConfig(w io.Writer) error
+	// RestoreComposeBackup swaps the previous version of the service configuration back in
+	RestoreComposeBackup() error
+	// MigrateComposeFile rewrites deprecated patterns in the service configuration from older versions and reports if anything changed
+	MigrateComposeFile() (bool, error)
+	// DiffComposeFiles compares two docker-compose files service by service and returns a readable description of the differences
+	DiffComposeFiles(pathA string, pathB string) (string, error)
 	// StopServices should stop the listed services from running
 	StopServices(services []string, deleteImages bool) error
 	// RemoveServices should stop and remove services from the configuration so that they aren't started again
 	RemoveServices(services []string) error
 	// StartServices should build images if needed and start the associated containers
 	StartServices(services []string, rebuildOnStart bool) error
-	// BuildServices should re-build specific images and start those new containers
-	BuildServices(services []string) error
+	// ScaleService runs the specified number of instances of a 3rd party service
+	ScaleService(service string, replicas int) error
+	// BuildServices should re-build specific images and start those new containers, building up to maxParallel at a time
+	BuildServices(services []string, maxParallel int, noCache bool) error
+	// BuildOnly should build the images for specific services without touching their running containers
+	BuildOnly(services []string, noCache bool) error
 	// GetInstalled3rdPartyServicesOnDisk returns the names of the installed services on disk
 	GetInstalled3rdPartyServicesOnDisk() ([]string, error)
 	// GetAllExistingNonMythicServiceNames reads current configuration and returns all non-mythic services
@@ -52,16 +109,49 @@ type CLIManager interface {
 	GetPathTo3rdPartyServicesOnDisk() string
 	// GetHealthCheck returns the output from the health checks of the specified services
 	GetHealthCheck(services []string)
+	// Doctor runs a series of checks on the environment Mythic runs in and returns the results with hints to fix problems
+	Doctor() []Diagnostic
+	// InspectService returns the curated container details for a service, like its image, state, mounts, and ports
+	InspectService(service string) (ServiceInspect, error)
+	// GetContainerEnv returns the environment variables a service's container is running with, with secrets redacted
+	GetContainerEnv(service string) (map[string]string, error)
+	// SetServiceEnvOverride sets an environment variable for one service that takes precedence over .env
+	SetServiceEnvOverride(service string, key string, value string) error
+	// RemoveServiceEnvOverride removes an environment variable override from SetServiceEnvOverride
+	RemoveServiceEnvOverride(service string, key string) error
+	// OverallHealth returns if all Mythic and installed services are healthy along with each service's status
+	OverallHealth() (bool, map[string]string, error)
 	// BuildUI a new instance of the Mythic React UI and save it in the mythic-react-docker folder
 	BuildUI() error
-	// GetLogs fetches logCount of the most recent logs from the service container
-	GetLogs(service string, logCount int, follow bool)
+	// GetLogs writes logCount of the most recent logs from the service container to w, or all of them if logCount is 0
+	GetLogs(w io.Writer, service string, logCount int, follow bool, options *LogOptions)
+	// GetLogsMulti writes logs from multiple service containers to w at once, prefixing each line with the service name
+	GetLogsMulti(w io.Writer, services []string, logCount int, follow bool, options *LogOptions)
 	// TestPorts check to make sure all ports are available for services to use
 	TestPorts(services []string)
-	// PrintConnectionInfo lists out connection information for the various services (web endpoints, open ports, etc)
-	PrintConnectionInfo()
+	// GetConnectionInfo returns the effective connection information for the various services, masking passwords unless showSecrets is set
+	GetConnectionInfo(showSecrets bool) []ConnectionInfo
+	// ConnectionInfoJSON writes the GetConnectionInfo entries to w as JSON for scripts and provisioning tools
+	ConnectionInfoJSON(w io.Writer, showSecrets bool) error
+	// GetPublishedPorts returns every port mapping in the docker-compose service definitions with the .env values filled in
+	GetPublishedPorts() ([]PortMapping, error)
+	// PrintConnectionInfo lists out connection information for the various services (web endpoints, open ports, logins, etc)
+	PrintConnectionInfo(showSecrets bool)
 	// Status prints out the current status of all the containers and volumes in use
-	Status(verbose bool)
+	Status(verbose bool, filter string)
+	// GetOrphanedContainers returns the containers left behind by services that aren't in the configuration or on disk anymore
+	GetOrphanedContainers() ([]string, error)
+	// RemoveOrphanedContainers removes the containers from GetOrphanedContainers
+	RemoveOrphanedContainers() error
+	// FindOrphans returns the volumes, images, and installed service folders that don't belong to any service in docker-compose
+	FindOrphans() (volumes []string, images []string, folders []string, err error)
+	// MissingImages returns the services in the configuration whose images haven't been built or pulled yet
+	MissingImages() ([]MissingImage, error)
+	// GetServiceStatuses returns the state of the Mythic and installed services that have containers, are in docker-compose, or are on disk,
+	// limited to those matching filter
+	GetServiceStatuses(filter string) ([]ServiceInfo, error)
+	// ListServices returns information about all the installed 3rd party services
+	ListServices() ([]ServiceInfo, error)
 	// PrintAllServices prints out all the 3rd party services on disk and currently installed
 	PrintAllServices()
 	// ResetDatabase deletes the current database or volume
@@ -74,19 +164,222 @@ type CLIManager interface {
 	BackupFiles(backupPath string, useVolume bool) error
 	// RestoreFiles restores a saved copy of Mythic's uploads/downloads from the specified path
 	RestoreFiles(backupPath string, useVolume bool) error
+	// GetVolumeInformation returns the size, usage, and location of all the volumes Mythic uses
+	GetVolumeInformation() ([]VolumeInfo, error)
+	// GetServiceDiskUsage returns the image, container, and volume disk usage for each service, sorted by total size
+	GetServiceDiskUsage() ([]ServiceDiskUsage, error)
 	// PrintVolumeInformation prints out all the volumes in use by Mythic
 	PrintVolumeInformation()
-	// RemoveVolume removes the named volume
-	RemoveVolume(volumeName string) error
+	// RemoveVolume removes the named volume and any containers using it, asking for confirmation first unless force is true
+	RemoveVolume(volumeName string, force bool) error
+	// RemoveVolumes removes all the named volumes and any containers using them without asking, continuing past failures
+	RemoveVolumes(volumeNames []string) error
+	// RemoveServiceVolumes removes an installed service's <service>_volume* volumes and drops them from docker-compose
+	RemoveServiceVolumes(service string) error
 	// CopyIntoVolume copies from a source io.Reader to the destination filename on the destination volume
 	CopyIntoVolume(sourceFile string, destinationFileName string, destinationVolume string) error
+	// CopyFilePathIntoVolume copies a single local file to the destination filename on the destination volume
+	CopyFilePathIntoVolume(localPath string, destinationFileName string, destinationVolume string) error
 	// CopyFromVolume copies from the source filename in the volume to the destination filename outside of the volume
 	CopyFromVolume(sourceVolumeName string, sourceFileName string, destinationName string) error
+	// CopyDirIntoVolume recursively copies the contents of a local directory into a directory on the destination volume
+	CopyDirIntoVolume(sourceDir string, destinationDir string, destinationVolume string) error
+	// CopyDirFromVolume recursively copies the contents of a directory on the volume into a local directory
+	CopyDirFromVolume(sourceVolumeName string, sourceDir string, destinationDir string) error
+	// SnapshotVolume saves a copy of the volume's contents locally and returns an ID to restore it with later
+	SnapshotVolume(volumeName string) (string, error)
+	// RestoreVolumeSnapshot replaces the contents of the volume with a snapshot from SnapshotVolume
+	RestoreVolumeSnapshot(volumeName string, snapshotID string) error
+	// CloneVolume copies the contents of an existing volume into a new volume
+	CloneVolume(sourceVolume string, destinationVolume string) error
+	// ListVolumeSnapshots returns the volume snapshots that have been saved
+	ListVolumeSnapshots() ([]VolumeSnapshot, error)
+	// CopyIntoContainer copies a local file or directory to a path inside the service's running container
+	CopyIntoContainer(service string, localPath string, containerPath string) error
+	// CopyFromContainer copies a file or directory from inside the service's running container to a local path
+	CopyFromContainer(service string, containerPath string, localPath string) error
+	// ShellIntoService opens an interactive shell in the service's running container
+	ShellIntoService(service string) error
+	// GetServicePort returns the host port the service is published on, falling back to the port configured in .env
+	GetServicePort(service string) (uint16, error)
+	// WaitForPort blocks until the service's published host:port accepts connections or timeout passes
+	WaitForPort(service string, timeout time.Duration) error
+}
+
+// Healthcheck is a docker-compose healthcheck for a service. Zero durations and retries use Docker's defaults.
+type Healthcheck struct {
+	// Test is the command to run inside the container with its shell, a non-zero exit code is unhealthy
+	Test        string
+	Interval    time.Duration
+	Timeout     time.Duration
+	Retries     int
+	StartPeriod time.Duration
+}
+
+// VolumeSnapshot describes a saved copy of a volume's contents
+type VolumeSnapshot struct {
+	Volume  string    `json:"volume"`
+	ID      string    `json:"id"`
+	Size    int64     `json:"size"`
+	Created time.Time `json:"created"`
+}
+
+// VolumeInfo describes a volume used by a Mythic service
+type VolumeInfo struct {
+	Name       string `json:"name"`
+	SizeBytes  int64  `json:"size_bytes"`
+	Container  string `json:"container"`
+	RefCount   int64  `json:"ref_count"`
+	Status     string `json:"status"`
+	Mountpoint string `json:"mountpoint"`
+}
+
+// ServiceDiskUsage is how much disk a service's image, container writable layer, and volumes take up
+type ServiceDiskUsage struct {
+	Service        string   `json:"service"`
+	ImageBytes     int64    `json:"image_bytes"`
+	ContainerBytes int64    `json:"container_bytes"`
+	VolumeBytes    int64    `json:"volume_bytes"`
+	TotalBytes     int64    `json:"total_bytes"`
+	Volumes        []string `json:"volumes"`
+}
+
+// ConnectionInfo describes how to reach one of Mythic's services
+type ConnectionInfo struct {
+	Service      string `json:"service"`
+	URL          string `json:"url"`
+	Scheme       string `json:"scheme"`
+	BoundLocally bool   `json:"bound_locally"`
+	// Additional services are the supporting ones operators don't normally browse to
+	Additional bool `json:"additional"`
+	// Username and Password are the login for the service from the .env, Password is masked unless secrets are shown
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// PortMapping is a port a service publishes on the host according to docker-compose.
+// Ports are strings since docker-compose allows ranges like 8000-8010.
+type PortMapping struct {
+	Service string `json:"service"`
+	// HostPort is empty when Docker picks a random host port
+	HostPort      string `json:"host_port"`
+	ContainerPort string `json:"container_port"`
+	Protocol      string `json:"protocol"`
+	BindAddress   string `json:"bind_address"`
+	// HostNetwork services share the host's network, so every port they listen on is exposed and there are no mappings
+	HostNetwork bool `json:"host_network"`
+}
+
+// LogOptions are the optional settings for fetching logs
+type LogOptions struct {
+	// Filter only shows matching lines when set
+	Filter *LogFilter
+	// Timestamps prefixes each line with the time it was logged
+	Timestamps bool
+	// Since and Until limit logs to a time range, either as a timestamp (2024-01-02T15:04:05Z) or relative duration (42m)
+	Since string
+	Until string
+}
+
+// getFilter returns the log filter, if any
+func (o *LogOptions) getFilter() *LogFilter {
+	if o == nil {
+		return nil
+	}
+	return o.Filter
+}
+
+// LogFilter limits log output to the lines matching (or with Invert, not matching) Pattern
+type LogFilter struct {
+	Pattern *regexp.Regexp
+	Invert  bool
+}
+
+// Matches checks if a single log line should be displayed
+func (f *LogFilter) Matches(line string) bool {
+	if f == nil || f.Pattern == nil {
+		return true
+	}
+	return f.Pattern.MatchString(line) != f.Invert
+}
+
+// Diagnostic is the result of one of the Doctor checks
+type Diagnostic struct {
+	Name   string           `json:"name"`
+	Status DiagnosticStatus `json:"status"`
+	// Message says what was found and Hint says how to fix it when the check didn't pass
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+type DiagnosticStatus string
+
+const (
+	DiagnosticPass DiagnosticStatus = "pass"
+	DiagnosticWarn DiagnosticStatus = "warn"
+	DiagnosticFail DiagnosticStatus = "fail"
+)
+
+// ServiceInspect is the curated detail about a service's container
+type ServiceInspect struct {
+	Service       string         `json:"service"`
+	ContainerID   string         `json:"container_id"`
+	Image         string         `json:"image"`
+	State         string         `json:"state"`
+	Health        string         `json:"health"`
+	Mounts        []ServiceMount `json:"mounts"`
+	Ports         []string       `json:"ports"`
+	Env           []string       `json:"env"`
+	RestartPolicy string         `json:"restart_policy"`
+	// CPUs and MemoryMB are 0 when there's no limit
+	CPUs     float64 `json:"cpus"`
+	MemoryMB int64   `json:"memory_mb"`
+	// Raw is the manager specific inspect output (with secrets in the environment redacted)
+	Raw interface{} `json:"-"`
+}
+
+// ServiceMount is a volume or folder mounted into a service's container
+type ServiceMount struct {
+	Type        string `json:"type"`
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+	ReadOnly    bool   `json:"read_only"`
+}
+
+// ServiceInfo describes an installed 3rd party service and where it shows up
+type ServiceInfo struct {
+	Name            string `json:"name"`
+	InCompose       bool   `json:"in_compose"`
+	OnDisk          bool   `json:"on_disk"`
+	ImageBuilt      bool   `json:"image_built"`
+	ContainerStatus string `json:"container_status"`
+	// State and Health are only filled in by GetServiceStatuses
+	State  string `json:"state,omitempty"`
+	Health string `json:"health,omitempty"`
+}
+
+// Status is ContainerStatus, so status --format templates can use {{.Status}} like docker ps --format
+func (s ServiceInfo) Status() string {
+	return s.ContainerStatus
+}
+
+// MissingImage is a service whose image isn't available yet
+type MissingImage struct {
+	Service string `json:"service"`
+	Image   string `json:"image"`
+	// NeedsBuild is true when the service has a build block, otherwise the image is pulled from a registry
+	NeedsBuild bool `json:"needs_build"`
 }
 
 var currentManager CLIManager
 
 func Initialize() {
+	if noPTY, err := strconv.ParseBool(os.Getenv("MYTHIC_CLI_NO_PTY")); err == nil {
+		DisablePTY = noPTY
+	}
+	if composeFile := os.Getenv("MYTHIC_CLI_COMPOSE_FILE"); composeFile != "" {
+		ComposeFilePath = composeFile
+	}
 	envManager := config.GetMythicEnv().GetString("global_manager")
 	switch envManager {
 	case "docker":
@@ -94,6 +387,14 @@ func Initialize() {
 			InstalledServicesPath:   "InstalledServices",
 			InstalledServicesFolder: filepath.Join(utils.GetCwdFromExe(), "InstalledServices"),
 		}
+	case "kubernetes":
+		currentManager = &KubernetesManager{
+			Namespace: config.GetMythicEnv().GetString("kubernetes_namespace"),
+			compose: &DockerComposeManager{
+				InstalledServicesPath:   "InstalledServices",
+				InstalledServicesFolder: filepath.Join(utils.GetCwdFromExe(), "InstalledServices"),
+			},
+		}
 	default:
 		log.Fatalf("[-] Unknown manger specified in .env for global_manager")
 	}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// missingImagesCmd represents the missing-images command
+var missingImagesCmd = &cobra.Command{
+	Use:   "missing-images",
+	Short: "List the services whose images haven't been built or pulled yet",
+	Long: `Run this command to check every Mythic and installed service in docker-compose for its image.
+Services with a build context need a locally built image, and services that use a prebuilt image (the default for Mythic's services) need that image pulled.
+This exits with an error if anything is missing, so CI can build ahead of time or fail fast before starting Mythic.`,
+	Run:  missingImages,
+	Args: cobra.NoArgs,
+}
+
+var missingImagesJSON bool
+
+func init() {
+	rootCmd.AddCommand(missingImagesCmd)
+	missingImagesCmd.Flags().BoolVar(
+		&missingImagesJSON,
+		"json",
+		false,
+		`Output the missing images as a JSON list of service, image, and needs_build`,
+	)
+}
+
+func missingImages(cmd *cobra.Command, args []string) {
+	missing, err := internal.PrintMissingImages(missingImagesJSON)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+	if missing {
+		os.Exit(1)
+	}
+}
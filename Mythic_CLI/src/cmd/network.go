@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// networkCmd represents the network command
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Interact with the networks Mythic services use",
+	Long:  `Run this command to interact with the networks defined for Mythic services in docker-compose`,
+	Run:   networks,
+}
+
+func init() {
+	rootCmd.AddCommand(networkCmd)
+}
+
+func networks(cmd *cobra.Command, args []string) {
+
+}
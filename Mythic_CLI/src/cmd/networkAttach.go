@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// networkAttach represents the network attach command
+var networkAttach = &cobra.Command{
+	Use:   "attach [service name] [network name]",
+	Short: "Connect a service to a named network",
+	Long: `Run this command to connect a service to a network, like an existing bridge on a specific subnet.
+Networks that aren't defined in docker-compose yet are added as external networks, so create them first with 'docker network create'.
+The service stays on its default network too. Installed services use host networking and can't be attached to other networks.`,
+	Run:  networkAttachCommand,
+	Args: cobra.ExactArgs(2),
+}
+
+func init() {
+	networkCmd.AddCommand(networkAttach)
+}
+
+func networkAttachCommand(cmd *cobra.Command, args []string) {
+	if err := internal.NetworkAttach(args[0], args[1]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
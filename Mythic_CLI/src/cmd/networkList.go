@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// networkList represents the network ls command
+var networkList = &cobra.Command{
+	Use:   "ls",
+	Short: "list the networks defined in docker-compose",
+	Long:  `Run this command to list the networks defined in docker-compose. When none are defined, services use docker compose's default network.`,
+	Run:   networksListCommand,
+}
+
+var networkListJSON bool
+
+func init() {
+	networkCmd.AddCommand(networkList)
+	networkList.Flags().BoolVar(
+		&networkListJSON,
+		"json",
+		false,
+		`Output the network information as JSON`,
+	)
+}
+
+func networksListCommand(cmd *cobra.Command, args []string) {
+	internal.NetworksList(networkListJSON)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// orphansCmd represents the orphans command
+var orphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "Report what's left behind by services that aren't in docker-compose",
+	Long: `Run this command to list the containers, *_volume volumes, images, and InstalledServices folders that don't belong to any service in docker-compose.
+Nothing is removed, each section says how to clean it up. Images are only checked if they're from Mythic's registries or docker compose built them for this install.`,
+	Run:  orphans,
+	Args: cobra.NoArgs,
+}
+
+var orphansJSON bool
+
+func init() {
+	rootCmd.AddCommand(orphansCmd)
+	orphansCmd.Flags().BoolVar(
+		&orphansJSON,
+		"json",
+		false,
+		`Output the orphaned resources as JSON`,
+	)
+}
+
+func orphans(cmd *cobra.Command, args []string) {
+	if err := internal.PrintOrphans(orphansJSON); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// platformCmd represents the platform command
+var platformCmd = &cobra.Command{
+	Use:   "platform [service name] [platform]",
+	Short: "Set the platform a service is built and run for",
+	Long: `Run this command to force a service to build and run for a specific platform, like 'platform apollo linux/amd64'.
+This is useful on Apple Silicon or other arm64 hosts when a service only works on amd64.
+Use --reset to go back to the host's architecture. Rebuild the service to apply the change.`,
+	Run:  platform,
+	Args: cobra.RangeArgs(1, 2),
+}
+
+var platformReset bool
+
+func init() {
+	rootCmd.AddCommand(platformCmd)
+	platformCmd.Flags().BoolVar(
+		&platformReset,
+		"reset",
+		false,
+		`Remove the platform so the service is built for the host's architecture`,
+	)
+}
+
+func platform(cmd *cobra.Command, args []string) {
+	platformName := ""
+	if len(args) == 2 {
+		if platformReset {
+			fmt.Printf("[-] Either give a platform or use --reset, not both\n")
+			os.Exit(1)
+		}
+		platformName = args[1]
+	} else if !platformReset {
+		fmt.Printf("[-] Give a platform (ex: linux/amd64) or use --reset to remove it\n")
+		os.Exit(1)
+	}
+	if err := internal.ServicePlatform(args[0], platformName); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// portCmd represents the port command
+var portCmd = &cobra.Command{
+	Use:   "port [service]",
+	Short: "Print the host port a service is published on",
+	Long: `Run this command to print the host port a service's running container is published on, for example:
+	./mythic-cli port mythic_server
+If the service isn't running, this prints the port configured in .env instead. Only the port is printed so it can be used in scripts.`,
+	Run:  port,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(portCmd)
+}
+
+func port(cmd *cobra.Command, args []string) {
+	servicePort, err := internal.GetServicePort(args[0])
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(servicePort)
+}
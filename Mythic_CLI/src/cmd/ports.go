@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// portsCmd represents the ports command
+var portsCmd = &cobra.Command{
+	Use:   "ports",
+	Short: "List the ports every service publishes on the host",
+	Long: `Run this command to list every port mapping in docker-compose with the .env values filled in, whether or not the service is running.
+Installed services use host networking, so anything they listen on is exposed and they show as 'host network'.`,
+	Run: ports,
+}
+
+var portsJSON bool
+
+func init() {
+	rootCmd.AddCommand(portsCmd)
+	portsCmd.Flags().BoolVar(
+		&portsJSON,
+		"json",
+		false,
+		`Output the port mappings as JSON`,
+	)
+}
+
+func ports(cmd *cobra.Command, args []string) {
+	if err := internal.PublishedPortsList(portsJSON); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/config"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Save, start, and stop named groups of services",
+	Long: `Run this command to list the saved service profiles. Use subcommands to save a profile
+or start and stop all of the services in it at once.`,
+	Run: profileList,
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+}
+
+func profileList(cmd *cobra.Command, args []string) {
+	profiles := config.GetServiceProfiles()
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	writer := new(tabwriter.Writer)
+	writer.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	fmt.Fprintln(writer, "PROFILE\tSERVICES")
+	for _, name := range names {
+		fmt.Fprintf(writer, "%s\t%s\n", name, strings.Join(profiles[name], ", "))
+	}
+	writer.Flush()
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"log"
+)
+
+// profileSaveCmd represents the profile save command
+var profileSaveCmd = &cobra.Command{
+	Use:   "save [profile name] [container names]",
+	Short: "Save a named group of services",
+	Long:  `Run this command to save a group of services under a profile name so they can be started and stopped together.`,
+	Run:   profileSave,
+	Args:  cobra.MinimumNArgs(2),
+}
+
+func init() {
+	profileCmd.AddCommand(profileSaveCmd)
+}
+
+func profileSave(cmd *cobra.Command, args []string) {
+	if err := internal.SaveProfile(args[0], args[1:]); err != nil {
+		log.Fatalf("[-] Failed to save profile: %v\n", err)
+	}
+}
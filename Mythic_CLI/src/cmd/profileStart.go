@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"log"
+)
+
+// profileStartCmd represents the profile start command
+var profileStartCmd = &cobra.Command{
+	Use:   "start [profile name]",
+	Short: "Start all of the services in a profile",
+	Long:  `Run this command to start all of the services saved in a profile.`,
+	Run:   profileStart,
+	Args:  cobra.ExactArgs(1),
+}
+
+func init() {
+	profileCmd.AddCommand(profileStartCmd)
+}
+
+func profileStart(cmd *cobra.Command, args []string) {
+	if err := internal.StartProfile(args[0]); err != nil {
+		log.Fatalf("[-] Failed to start profile: %v\n", err)
+	}
+}
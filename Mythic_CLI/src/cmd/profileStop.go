@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"log"
+)
+
+// profileStopCmd represents the profile stop command
+var profileStopCmd = &cobra.Command{
+	Use:   "stop [profile name]",
+	Short: "Stop all of the services in a profile",
+	Long:  `Run this command to stop all of the services saved in a profile.`,
+	Run:   profileStop,
+	Args:  cobra.ExactArgs(1),
+}
+
+func init() {
+	profileCmd.AddCommand(profileStopCmd)
+}
+
+func profileStop(cmd *cobra.Command, args []string) {
+	if err := internal.StopProfile(args[0]); err != nil {
+		log.Fatalf("[-] Failed to stop profile: %v\n", err)
+	}
+}
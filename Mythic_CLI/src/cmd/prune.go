@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove dangling images, build cache, and orphaned volumes",
+	Long: `Run this command to free up disk space by removing dangling images. 
+Optionally also remove unused build cache and volumes that belong to services no longer in docker-compose.`,
+	Run: prune,
+}
+var pruneVolumes bool
+var pruneBuildCache bool
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+	pruneCmd.Flags().BoolVarP(
+		&pruneVolumes,
+		"volumes",
+		"v",
+		false,
+		`Also remove [service]_volume volumes whose service isn't in docker-compose`,
+	)
+	pruneCmd.Flags().BoolVarP(
+		&pruneBuildCache,
+		"build-cache",
+		"b",
+		false,
+		`Also remove unused build cache`,
+	)
+	pruneCmd.Flags().BoolVarP(
+		&force,
+		"force",
+		"f",
+		false,
+		`Don't prompt for confirmation before deleting volumes`,
+	)
+}
+
+func prune(cmd *cobra.Command, args []string) {
+	if err := internal.DockerPrune(pruneVolumes, pruneBuildCache, force); err != nil {
+		fmt.Printf("[-] Failed to prune: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// pullCmd represents the pull command
+var pullCmd = &cobra.Command{
+	Use:   "pull [registry] [container names]",
+	Short: "Pull the specified container's images from a registry",
+	Long:  `Run this command to pull the specified container's backing images from the registry prefix (ex: registry.local:5000/mythic) that were pushed with the 'push' command. If you don't specify any container names, all installed and Mythic services are pulled.`,
+	Run:   pull,
+	Args:  cobra.MinimumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(pullCmd)
+}
+
+func pull(cmd *cobra.Command, args []string) {
+	if err := internal.DockerPull(args[0], args[1:]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("[+] Successfully pulled image(s)\n")
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// pushCmd represents the push command
+var pushCmd = &cobra.Command{
+	Use:   "push [registry] [container names]",
+	Short: "Push the specified container's images to a registry",
+	Long: `Run this command to tag the specified container's backing images with the registry prefix (ex: registry.local:5000/mythic) and push them. If you don't specify any container names, all installed and Mythic services are pushed.
+You can then use the 'pull' command to fetch these images on a separate server.`,
+	Run:  push,
+	Args: cobra.MinimumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(pushCmd)
+}
+
+func push(cmd *cobra.Command, args []string) {
+	if err := internal.DockerPush(args[0], args[1:]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("[+] Successfully pushed image(s)\n")
+}
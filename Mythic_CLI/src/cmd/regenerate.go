@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// regenerateCmd represents the regenerate command
+var regenerateCmd = &cobra.Command{
+	Use:   "regenerate [service name]",
+	Short: "Rebuild an installed service's docker-compose entry",
+	Long: `Run this command to throw away an installed service's docker-compose entry and rebuild it from the service's folder in InstalledServices.
+This is a targeted repair for an entry that was broken by hand or by a bad 'add', other services aren't touched.`,
+	Run:  regenerate,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(regenerateCmd)
+}
+
+func regenerate(cmd *cobra.Command, args []string) {
+	if err := internal.RegenerateServiceConfig(args[0]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
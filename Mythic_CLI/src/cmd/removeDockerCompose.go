@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
 	"github.com/spf13/cobra"
 	"log"
 )
@@ -25,5 +26,5 @@ func removeDockerCompose(cmd *cobra.Command, args []string) {
 		log.Printf("[-] Failed to remove service")
 		return
 	}
-	log.Printf("[+] Successfully removed service")
+	utils.LogInfo("[+] Successfully removed service")
 }
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+// removeImagesCmd represents the remove-images command
+var removeImagesCmd = &cobra.Command{
+	Use:   "remove-images",
+	Short: "Remove unused dangling images or old Mythic images",
+	Long: `Run this command to remove dangling (<none>:<none>) images that aren't used by any containers.
+With --older-than, images for Mythic services and installed services that were created before the cutoff are removed instead.
+Base images and images from other projects are never removed this way.`,
+	Run:  removeImages,
+	Args: cobra.NoArgs,
+}
+var removeImagesOlderThan time.Duration
+var removeImagesKeepRunning bool
+var removeImagesDryRun bool
+
+func init() {
+	rootCmd.AddCommand(removeImagesCmd)
+	removeImagesCmd.Flags().DurationVar(
+		&removeImagesOlderThan,
+		"older-than",
+		0,
+		`Remove Mythic service images created more than this long ago (ex: 720h)`,
+	)
+	removeImagesCmd.Flags().BoolVar(
+		&removeImagesKeepRunning,
+		"keep-running",
+		true,
+		`With --older-than, skip images used by running containers`,
+	)
+	removeImagesCmd.Flags().BoolVar(
+		&removeImagesDryRun,
+		"dry-run",
+		false,
+		`Only list the dangling images that would be removed`,
+	)
+}
+
+func removeImages(cmd *cobra.Command, args []string) {
+	if removeImagesOlderThan > 0 && removeImagesDryRun {
+		fmt.Printf("[-] --dry-run can't be combined with --older-than\n")
+		os.Exit(1)
+	}
+	if err := internal.DockerRemoveImages(removeImagesOlderThan, removeImagesKeepRunning, removeImagesDryRun); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// composeRestoreCmd represents the restore compose command
+var composeRestoreCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "swap docker-compose.yml with the backup from before its last change",
+	Long:  `Run this command to swap docker-compose.yml with docker-compose.yml.bak, which is saved every time mythic-cli changes docker-compose.yml. Running it again swaps them back.`,
+	Run:   composeRestore,
+	Args:  cobra.NoArgs,
+	// the file being restored might not parse, and initializing would rewrite it and its backup first
+	Annotations: map[string]string{skipInitializeAnnotation: "true"},
+}
+
+func init() {
+	restoreCmd.AddCommand(composeRestoreCmd)
+}
+
+func composeRestore(cmd *cobra.Command, args []string) {
+	internal.ComposeRestore()
+}
@@ -5,7 +5,10 @@ import (
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
 	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
 	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
+	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 import (
@@ -19,8 +22,49 @@ var rootCmd = &cobra.Command{
 	Long: `Mythic CLI is a command line interface for managing the Mythic application and associated containers and services.
 Commands are grouped by their use and all support '-h' for help.
 For a list of available services to install, check out: https://mythicmeta.github.io/overview/`,
+	PersistentPreRun: validateGlobalFlags,
 }
 
+// skipInitializeAnnotation marks commands that shouldn't generate or update the docker-compose file before running,
+// like doctor, which needs to report the problems that initializing would otherwise exit on
+const skipInitializeAnnotation = "mythic-cli/skip-initialize"
+
+// validateGlobalFlags makes sure every --env value is in the form KEY=VALUE and sets the log level,
+// then makes sure the docker-compose file is up to date before the command runs
+func validateGlobalFlags(cmd *cobra.Command, args []string) {
+	for _, entry := range manager.ExtraCommandEnv {
+		if key, _, found := strings.Cut(entry, "="); !found || key == "" {
+			fmt.Printf("[-] --env values must be in the form KEY=VALUE: %s\n", entry)
+			os.Exit(1)
+		}
+	}
+	if quietLogging && verboseLogging {
+		fmt.Printf("[-] --quiet and --verbose can't be used together\n")
+		os.Exit(1)
+	}
+	if quietLogging {
+		utils.SetLogLevel(utils.LogLevelQuiet)
+	} else if verboseLogging {
+		utils.SetLogLevel(utils.LogLevelVerbose)
+	}
+	// --compose-file has to be resolved before initializing, since that generates and migrates the compose file
+	if manager.ComposeFilePath != "" {
+		if !filepath.IsAbs(manager.ComposeFilePath) {
+			manager.ComposeFilePath = filepath.Join(utils.GetCwdFromExe(), manager.ComposeFilePath)
+		}
+		if _, err := os.Stat(filepath.Dir(manager.ComposeFilePath)); err != nil {
+			fmt.Printf("[-] Folder for --compose-file %s doesn't exist: %v\n", manager.ComposeFilePath, err)
+			os.Exit(1)
+		}
+	}
+	if _, skip := cmd.Annotations[skipInitializeAnnotation]; !skip {
+		internal.Initialize()
+	}
+}
+
+var quietLogging bool
+var verboseLogging bool
+
 var force bool
 var branch string
 
@@ -36,5 +80,36 @@ func init() {
 	// Create or parse the Docker ``.env`` file
 	config.Initialize()
 	manager.Initialize()
-	internal.Initialize()
+	rootCmd.PersistentFlags().BoolVar(
+		&manager.DisablePTY,
+		"no-pty",
+		manager.DisablePTY,
+		`Don't use a PTY or progress bars for docker compose output, useful for clean logs in CI (or set MYTHIC_CLI_NO_PTY=true)`,
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&manager.ComposeFilePath,
+		"compose-file",
+		manager.ComposeFilePath,
+		`Use a docker-compose file other than docker-compose.yml in the Mythic folder (or set MYTHIC_CLI_COMPOSE_FILE)`,
+	)
+	rootCmd.PersistentFlags().StringArrayVarP(
+		&manager.ExtraCommandEnv,
+		"env",
+		"e",
+		manager.ExtraCommandEnv,
+		`Set KEY=VALUE in the environment of docker and docker compose for just this command, can be used multiple times`,
+	)
+	rootCmd.PersistentFlags().BoolVarP(
+		&quietLogging,
+		"quiet",
+		"q",
+		false,
+		`Only log warnings and errors, command output like status tables is still shown`,
+	)
+	rootCmd.PersistentFlags().BoolVar(
+		&verboseLogging,
+		"verbose",
+		false,
+		`Log extra detail that's useful when troubleshooting`,
+	)
 }
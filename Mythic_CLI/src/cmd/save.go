@@ -15,12 +15,21 @@ You can then use the 'load' command to load these images on a separate server th
 	Run: save,
 }
 
+var saveSeparately bool
+
 func init() {
 	rootCmd.AddCommand(saveCmd)
+	saveCmd.Flags().BoolVarP(
+		&saveSeparately,
+		"separate",
+		"s",
+		false,
+		`Save each image into its own <name>.tar file instead of one mythic_save.tar`,
+	)
 }
 
 func save(cmd *cobra.Command, args []string) {
-	if err := internal.DockerSave(args); err != nil {
+	if err := internal.DockerSave(args, saveSeparately); err != nil {
 		fmt.Printf("%v\n", err)
 	} else {
 		fmt.Printf("[+] Successfully saved file\n")
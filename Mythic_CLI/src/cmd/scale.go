@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+)
+
+// scaleCmd represents the scale command
+var scaleCmd = &cobra.Command{
+	Use:   "scale [service name] [replicas]",
+	Short: "Run multiple instances of a 3rd party service",
+	Long: `Run this command to change how many instances of an installed service (ex: a translation container or webhook handler) run at once.
+The count is saved in docker-compose so it's kept on the next start. Mythic's core services always run a single instance.`,
+	Run:  scale,
+	Args: cobra.ExactArgs(2),
+}
+
+func init() {
+	rootCmd.AddCommand(scaleCmd)
+}
+
+func scale(cmd *cobra.Command, args []string) {
+	replicas, err := strconv.Atoi(args[1])
+	if err != nil {
+		fmt.Printf("[-] replicas must be a number: %v\n", err)
+		os.Exit(1)
+	}
+	if err = internal.ServiceScale(args[0], replicas); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
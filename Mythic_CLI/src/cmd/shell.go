@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// shellCmd represents the shell command
+var shellCmd = &cobra.Command{
+	Use:   "shell [service]",
+	Short: "Open an interactive shell in a running service",
+	Long: `Run this command to get a shell inside a service's running container, for example:
+	./mythic-cli shell mythic_server
+This uses /bin/bash when the container has it and /bin/sh otherwise. Exit the shell to return.`,
+	Run:  shellIntoService,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(shellCmd)
+}
+
+func shellIntoService(cmd *cobra.Command, args []string) {
+	if err := internal.ShellIntoService(args[0]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
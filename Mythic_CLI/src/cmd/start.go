@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
 	"github.com/spf13/cobra"
+	"os"
 )
 
 // configCmd represents the config command
@@ -15,10 +18,25 @@ var startCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(startCmd)
+	startCmd.Flags().BoolVar(
+		&manager.RollbackOnFailedStart,
+		"rollback",
+		false,
+		`When rebuilding on start, restore a container's previous image if the new one doesn't come up healthy.
+Only applies when REBUILD_ON_START is true`,
+	)
+	startCmd.Flags().BoolVar(
+		&manager.SkipImageCheck,
+		"skip-image-check",
+		false,
+		`Skip checking if each service's image exists and let docker-compose build only missing images.
+This starts large installs faster, but an existing image is used even if it's out of date and base images aren't pulled first`,
+	)
 }
 
 func start(cmd *cobra.Command, args []string) {
 	if err := internal.ServiceStart(args); err != nil {
-
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
 	}
 }
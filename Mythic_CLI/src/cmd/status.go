@@ -7,12 +7,18 @@ import (
 
 // configCmd represents the config command
 var statusCmd = &cobra.Command{
-	Use:   "status",
+	Use:   "status [filter]",
 	Short: "Get current Mythic container status",
-	Long:  `Run this command to get the current status of the Mythic services and containers.`,
-	Run:   status,
+	Long: `Run this command to get the current status of the Mythic services and containers.
+To only show some services, pass a filter. A filter with glob characters has to match the whole name, anything else matches part of it, ex:
+	./mythic-cli status mythic_
+	./mythic-cli status "*http*"
+-v/--verbose here is status's own flag for showing more services, it takes the place of the global --verbose logging flag for this command.`,
+	Run:  status,
+	Args: cobra.MaximumNArgs(1),
 }
 var verbose bool
+var statusFormat string
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
@@ -21,10 +27,22 @@ func init() {
 		"verbose",
 		"v",
 		false,
-		`Display more verbose information about the status, including services installed and not running or those installed and not in docker-compose`,
+		`Display more verbose information about the status, including services installed and not running or those installed and not in docker-compose.
+This replaces the global --verbose logging flag for status`,
+	)
+	statusCmd.Flags().StringVar(
+		&statusFormat,
+		"format",
+		"",
+		`Print each service with a Go template instead of the tables, ex: '{{.Name}} {{.Status}}'.
+Fields are Name, State, Status, Health, InCompose, OnDisk, and ImageBuilt. Use '{{json .}}' for everything`,
 	)
 }
 
 func status(cmd *cobra.Command, args []string) {
-	internal.Status(verbose)
+	filter := ""
+	if len(args) > 0 {
+		filter = args[0]
+	}
+	internal.Status(verbose, filter, statusFormat)
 }
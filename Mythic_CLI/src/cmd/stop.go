@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +17,13 @@ adjust specific containers to stop.`,
 
 func init() {
 	rootCmd.AddCommand(stopCmd)
+	stopCmd.Flags().IntVarP(
+		&manager.StopTimeout,
+		"timeout",
+		"t",
+		manager.StopTimeout,
+		`Seconds to wait for containers to exit before killing them, defaults to Docker's 10s. A service's stop_grace_period in docker-compose takes precedence`,
+	)
 }
 
 func stop(cmd *cobra.Command, args []string) {
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/MythicMeta/Mythic_CLI/cmd/utils"
+	"github.com/spf13/cobra"
+	"log"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync docker compose with the services installed on disk",
+	Long: `Run this command to add installed service folders that are missing from docker-compose and remove docker-compose entries for services that are no longer on disk.
+By default both are done, use --add or --remove to only do one of them.`,
+	Run:  syncCompose,
+	Args: cobra.NoArgs,
+}
+
+var syncAdd bool
+var syncRemove bool
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().BoolVarP(
+		&syncAdd,
+		"add",
+		"a",
+		false,
+		`Only add services on disk that are missing from docker-compose`,
+	)
+	syncCmd.Flags().BoolVarP(
+		&syncRemove,
+		"remove",
+		"r",
+		false,
+		`Only remove docker-compose entries that don't have a service on disk`,
+	)
+}
+
+func syncCompose(cmd *cobra.Command, args []string) {
+	if !syncAdd && !syncRemove {
+		syncAdd = true
+		syncRemove = true
+	}
+	added, removed, err := internal.SyncComposeWithDisk(syncAdd, syncRemove)
+	for _, service := range added {
+		utils.LogInfo("[+] Added %s to docker-compose\n", service)
+	}
+	for _, service := range removed {
+		utils.LogInfo("[+] Removed %s from docker-compose\n", service)
+	}
+	if err != nil {
+		log.Printf("[-] Failed to sync docker-compose: %v\n", err)
+		return
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		utils.LogInfo("[+] docker-compose is already in sync with installed services\n")
+	}
+}
@@ -9,7 +9,7 @@ import (
 var uninstallCmd = &cobra.Command{
 	Use:   "uninstall [container name]",
 	Short: "uninstall services locally and remove them from disk",
-	Long:  `Run this command to uninstall a local Mythic service and remove its contents from disk`,
+	Long:  `Run this command to uninstall a local Mythic service, removing its docker-compose entry, its folder and documentation on disk, and its volumes`,
 	Run:   uninstall,
 }
 
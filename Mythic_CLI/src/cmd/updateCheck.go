@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+)
+
+// updateCheckCmd represents the update-check command
+var updateCheckCmd = &cobra.Command{
+	Use:   "update-check",
+	Short: "Check installed services for updates",
+	Long: `Run this command to compare the version of each installed agent and c2 profile against the repository it was installed from.
+This only reports available updates, use 'install github' to actually update a service.`,
+	Run: updateCheckServices,
+}
+
+func init() {
+	rootCmd.AddCommand(updateCheckCmd)
+}
+
+func updateCheckServices(cmd *cobra.Command, args []string) {
+	internal.PrintServiceUpdates()
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"log"
+)
+
+// LogLevel controls how much of the CLI's log output is shown
+type LogLevel int
+
+const (
+	// LogLevelQuiet only shows warnings and errors
+	LogLevelQuiet LogLevel = iota
+	// LogLevelNormal also shows the informational [*] and [+] messages logged with LogInfo
+	LogLevelNormal
+	// LogLevelVerbose also shows the extra detail logged with LogVerbose
+	LogLevelVerbose
+)
+
+var currentLogLevel = LogLevelNormal
+
+// SetLogLevel sets how much is logged by LogInfo and LogVerbose.
+// Warnings and errors logged directly with the log package, and command output that's printed directly
+// (tables, connection info, etc), are always shown.
+func SetLogLevel(level LogLevel) {
+	currentLogLevel = level
+}
+
+// GetLogLevel returns the level set with SetLogLevel, for output that doesn't go through LogInfo or LogVerbose like pull progress
+func GetLogLevel() LogLevel {
+	return currentLogLevel
+}
+
+// LogInfo logs progress and success messages ([*] and [+]), they're hidden with --quiet
+func LogInfo(format string, args ...interface{}) {
+	if currentLogLevel >= LogLevelNormal {
+		log.Printf(format, args...)
+	}
+}
+
+// LogVerbose logs detail that's only useful when troubleshooting, it's only shown with --verbose
+func LogVerbose(format string, args ...interface{}) {
+	if currentLogLevel >= LogLevelVerbose {
+		log.Printf(format, args...)
+	}
+}
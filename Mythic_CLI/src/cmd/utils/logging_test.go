@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestLogLevels(t *testing.T) {
+	buf := &bytes.Buffer{}
+	log.SetOutput(buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+		SetLogLevel(LogLevelNormal)
+	}()
+	tests := []struct {
+		name  string
+		level LogLevel
+		want  string
+	}{
+		{name: "quiet", level: LogLevelQuiet, want: ""},
+		{name: "normal", level: LogLevelNormal, want: "[*] info\n"},
+		{name: "verbose", level: LogLevelVerbose, want: "[*] info\n[*] detail\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			SetLogLevel(tt.level)
+			LogInfo("[*] info\n")
+			LogVerbose("[*] detail\n")
+			if got := buf.String(); got != tt.want {
+				t.Errorf("log output at level %d = %q, want %q", tt.level, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"github.com/MythicMeta/Mythic_CLI/cmd/config"
+	"github.com/MythicMeta/Mythic_CLI/cmd/manager"
 	"github.com/spf13/cobra"
 	"os"
 	"path/filepath"
@@ -22,6 +23,11 @@ func init() {
 
 func mythicVersion(cmd *cobra.Command, args []string) {
 	fmt.Printf("[*] mythic-cli version:    %s\n", config.Version)
+	if managerVersion, err := manager.GetManager().GetManagerVersion(); err != nil {
+		fmt.Printf("[!] Failed to get %s version: %v\n", manager.GetManager().GetManagerName(), err)
+	} else {
+		fmt.Printf("[*] Detected %s version: %s\n", manager.GetManager().GetManagerName(), managerVersion)
+	}
 	if fileContents, err := os.ReadFile("VERSION"); err != nil {
 		fmt.Printf("[!] Failed to get Mythic version: %v\n", err)
 	} else {
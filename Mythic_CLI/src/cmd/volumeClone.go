@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// volumeClone represents the volume clone command
+var volumeClone = &cobra.Command{
+	Use:   "clone [source volume] [destination volume]",
+	Short: "Copy a volume's contents into a new volume",
+	Long: `Run this command to create a new volume with a copy of everything in an existing volume.
+The destination volume can't already exist.`,
+	Run:  volumeCloneCommand,
+	Args: cobra.ExactArgs(2),
+}
+
+func init() {
+	volumeCmd.AddCommand(volumeClone)
+}
+
+func volumeCloneCommand(cmd *cobra.Command, args []string) {
+	if err := internal.DockerCloneVolume(args[0], args[1]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
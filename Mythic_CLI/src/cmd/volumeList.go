@@ -13,10 +13,18 @@ var volumeList = &cobra.Command{
 	Run:   volumesListCommand,
 }
 
+var volumeListJSON bool
+
 func init() {
 	volumeCmd.AddCommand(volumeList)
+	volumeList.Flags().BoolVar(
+		&volumeListJSON,
+		"json",
+		false,
+		`Output the volume information as JSON`,
+	)
 }
 
 func volumesListCommand(cmd *cobra.Command, args []string) {
-	internal.VolumesList()
+	internal.VolumesList(volumeListJSON)
 }
@@ -9,18 +9,36 @@ import (
 
 // configCmd represents the config command
 var volumeRm = &cobra.Command{
-	Use:   "rm",
-	Short: "Delete a volume and all of its contents",
-	Long:  `Run this command to delete a specific volume and all of its contents `,
-	Run:   volumesRmCommand,
+	Use:   "rm [volume name] [volume name] ...",
+	Short: "Delete volumes and all of their contents",
+	Long: `Run this command to delete specific volumes and all of their contents. Any containers using the volumes are removed too.
+When multiple volumes are specified, failures are reported at the end instead of stopping at the first one.`,
+	Run:  volumesRmCommand,
+	Args: cobra.MinimumNArgs(1),
 }
 
+var volumeRmForce bool
+
 func init() {
 	volumeCmd.AddCommand(volumeRm)
+	volumeRm.Flags().BoolVarP(
+		&volumeRmForce,
+		"force",
+		"f",
+		false,
+		`Don't prompt for confirmation before deleting the volumes and the containers using them`,
+	)
 }
 
 func volumesRmCommand(cmd *cobra.Command, args []string) {
-	err := internal.DockerRemoveVolume(args[0])
+	if len(args) > 1 {
+		if err := internal.DockerRemoveVolumes(args, volumeRmForce); err != nil {
+			fmt.Printf("[-] error removing volumes: \n%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	err := internal.DockerRemoveVolume(args[0], volumeRmForce)
 	if err != nil {
 		fmt.Printf("[-] error removing volume: \n%v\n", err)
 		os.Exit(1)
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// volumeSnapshot represents the volume snapshot command
+var volumeSnapshot = &cobra.Command{
+	Use:   "snapshot [volume name]",
+	Short: "Save a snapshot of a volume's contents",
+	Long: `Run this command to save a copy of everything in a volume to the volume_snapshots folder so it can be restored later.
+This is a quick save point (ex: before experimenting with mythic_postgres_volume), not a replacement for a database backup.
+Stop the services using the volume first if you need a consistent snapshot.`,
+	Run:  volumeSnapshotCommand,
+	Args: cobra.ExactArgs(1),
+}
+
+func init() {
+	volumeCmd.AddCommand(volumeSnapshot)
+}
+
+func volumeSnapshotCommand(cmd *cobra.Command, args []string) {
+	snapshotID, err := internal.DockerSnapshotVolume(args[0])
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("[*] Restore it with './mythic-cli volume restore_snapshot %s %s'\n", args[0], snapshotID)
+}
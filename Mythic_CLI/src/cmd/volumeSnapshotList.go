@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// volumeSnapshotList represents the volume snapshots command
+var volumeSnapshotList = &cobra.Command{
+	Use:   "snapshots",
+	Short: "List the saved volume snapshots",
+	Long:  `Run this command to list the volume snapshots that have been saved along with their sizes and when they were taken.`,
+	Run:   volumeSnapshotListCommand,
+}
+
+var volumeSnapshotListJSON bool
+
+func init() {
+	volumeCmd.AddCommand(volumeSnapshotList)
+	volumeSnapshotList.Flags().BoolVar(
+		&volumeSnapshotListJSON,
+		"json",
+		false,
+		`Output the snapshots as JSON`,
+	)
+}
+
+func volumeSnapshotListCommand(cmd *cobra.Command, args []string) {
+	if err := internal.VolumeSnapshotsList(volumeSnapshotListJSON); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// volumeSnapshotRestore represents the volume restore_snapshot command
+var volumeSnapshotRestore = &cobra.Command{
+	Use:   "restore_snapshot [volume name] [snapshot id]",
+	Short: "Replace a volume's contents with a saved snapshot",
+	Long: `Run this command to replace everything in a volume with a snapshot from './mythic-cli volume snapshot'.
+Anything added to the volume since the snapshot is removed. The services using the volume need to be stopped first.`,
+	Run:  volumeSnapshotRestoreCommand,
+	Args: cobra.ExactArgs(2),
+}
+
+func init() {
+	volumeCmd.AddCommand(volumeSnapshotRestore)
+}
+
+func volumeSnapshotRestoreCommand(cmd *cobra.Command, args []string) {
+	if err := internal.DockerRestoreVolumeSnapshot(args[0], args[1]); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/MythicMeta/Mythic_CLI/cmd/internal"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+// waitPortCmd represents the wait-port command
+var waitPortCmd = &cobra.Command{
+	Use:   "wait-port [service]",
+	Short: "Wait for a service's published port to accept connections",
+	Long: `Run this command to block until a service actually accepts connections on its published port, for example:
+	./mythic-cli wait-port mythic_nginx --timeout 5m
+The address is resolved from .env and the running container the same way the connection info is.
+This exits with an error if the port still isn't reachable when the timeout passes.`,
+	Run:  waitPort,
+	Args: cobra.ExactArgs(1),
+}
+
+var waitPortTimeout time.Duration
+
+func init() {
+	rootCmd.AddCommand(waitPortCmd)
+	waitPortCmd.Flags().DurationVar(
+		&waitPortTimeout,
+		"timeout",
+		2*time.Minute,
+		`How long to wait for the port to accept connections (ex: 30s, 5m)`,
+	)
+}
+
+func waitPort(cmd *cobra.Command, args []string) {
+	if err := internal.WaitForPort(args[0], waitPortTimeout); err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+}
@@ -164,15 +164,29 @@ func setConfigFromEnv(mythicEnv *viper.Viper) {
 	MythicConfig.PostgresPort = mythicEnv.GetUint("postgres_port")
 	MythicConfig.PostgresDB = mythicEnv.GetString("postgres_db")
 	MythicConfig.PostgresUser = mythicEnv.GetString("postgres_user")
-	MythicConfig.PostgresPassword = mythicEnv.GetString("postgres_password")
+	MythicConfig.PostgresPassword = getSecretFromEnv(mythicEnv, "postgres_password")
 	// rabbitmq configuration
 	MythicConfig.RabbitmqHost = mythicEnv.GetString("rabbitmq_host")
 	MythicConfig.RabbitmqPort = mythicEnv.GetUint("rabbitmq_port")
 	MythicConfig.RabbitmqUser = mythicEnv.GetString("rabbitmq_user")
-	MythicConfig.RabbitmqPassword = mythicEnv.GetString("rabbitmq_password")
+	MythicConfig.RabbitmqPassword = getSecretFromEnv(mythicEnv, "rabbitmq_password")
 	MythicConfig.RabbitmqVHost = mythicEnv.GetString("rabbitmq_vhost")
 	// jwt configuration
-	MythicConfig.JWTSecret = []byte(mythicEnv.GetString("jwt_secret"))
+	MythicConfig.JWTSecret = []byte(getSecretFromEnv(mythicEnv, "jwt_secret"))
+}
+
+// getSecretFromEnv reads a secret from the file named by its _file variant (ex: POSTGRES_PASSWORD_FILE for docker secrets)
+// if one is set, otherwise from the value itself
+func getSecretFromEnv(mythicEnv *viper.Viper, key string) string {
+	secretFile := mythicEnv.GetString(key + "_file")
+	if secretFile == "" {
+		return mythicEnv.GetString(key)
+	}
+	secret, err := os.ReadFile(secretFile)
+	if err != nil {
+		log.Fatalf("[-] Failed to read %s from %s: %v", key, secretFile, err)
+	}
+	return strings.TrimSpace(string(secret))
 }
 
 func getCwdFromExe() string {
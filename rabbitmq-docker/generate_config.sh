@@ -1,5 +1,9 @@
 #!/bin/sh
 
+if [ -n "$RABBITMQ_PASSWORD_FILE" ]
+then
+    RABBITMQ_PASSWORD=$(cat "$RABBITMQ_PASSWORD_FILE")
+fi
 cp /tmp/base_rabbitmq.conf /tmp/rabbitmq.conf
 echo -n "default_user = " >> /tmp/rabbitmq.conf
 echo "$RABBITMQ_USER" >> /tmp/rabbitmq.conf